@@ -56,6 +56,57 @@ const (
 	DeploymentReachabilityUnreachable = internal.DeploymentReachabilityUnreachable
 )
 
+const (
+	// LoadBalancingPolicyRoundRobin spreads calls across all healthy endpoints.
+	// This is the default.
+	//
+	// NOTE: Experimental
+	LoadBalancingPolicyRoundRobin = internal.LoadBalancingPolicyRoundRobin
+
+	// LoadBalancingPolicyPickFirst sends all calls to the first healthy endpoint
+	// in the configured order, falling back to the next one when it becomes
+	// unhealthy.
+	//
+	// NOTE: Experimental
+	LoadBalancingPolicyPickFirst = internal.LoadBalancingPolicyPickFirst
+)
+
+const (
+	// TrafficShapingModeBlock makes calls exceeding the limits wait until they
+	// are within them or their context is done. This is the default.
+	//
+	// NOTE: Experimental
+	TrafficShapingModeBlock = internal.TrafficShapingModeBlock
+
+	// TrafficShapingModeFailFast makes calls exceeding the limits fail
+	// immediately with a serviceerror.ResourceExhausted error.
+	//
+	// NOTE: Experimental
+	TrafficShapingModeFailFast = internal.TrafficShapingModeFailFast
+)
+
+const (
+	// ScheduleChangeUnchanged - The schedule already matches the desired options.
+	//
+	// NOTE: Experimental
+	ScheduleChangeUnchanged = internal.ScheduleChangeUnchanged
+
+	// ScheduleChangeCreate - The schedule does not exist and is created.
+	//
+	// NOTE: Experimental
+	ScheduleChangeCreate = internal.ScheduleChangeCreate
+
+	// ScheduleChangeUpdate - The schedule exists and differs from the desired options.
+	//
+	// NOTE: Experimental
+	ScheduleChangeUpdate = internal.ScheduleChangeUpdate
+
+	// ScheduleChangeDelete - The schedule is owned by the reconciler but not desired, and is deleted.
+	//
+	// NOTE: Experimental
+	ScheduleChangeDelete = internal.ScheduleChangeDelete
+)
+
 // WorkerDeploymentVersionDrainageStatus specifies the drainage status for a Worker
 // Deployment Version enabling users to decide when they can safely decommission this
 // Version.
@@ -219,6 +270,41 @@ type (
 	// ConnectionOptions are optional parameters that can be specified in ClientOptions
 	ConnectionOptions = internal.ConnectionOptions
 
+	// RetryBudgetOptions are options for a retry budget shared by all calls on a
+	// connection.
+	//
+	// NOTE: Experimental
+	RetryBudgetOptions = internal.RetryBudgetOptions
+
+	// HedgingOptions are options for hedged requests of idempotent reads.
+	//
+	// NOTE: Experimental
+	HedgingOptions = internal.HedgingOptions
+
+	// TrafficShapingOptions are options for limiting the rate and concurrency of
+	// client calls by class.
+	//
+	// NOTE: Experimental
+	TrafficShapingOptions = internal.TrafficShapingOptions
+
+	// TrafficClass is a set of gRPC methods that share rate and concurrency
+	// limits.
+	//
+	// NOTE: Experimental
+	TrafficClass = internal.TrafficClass
+
+	// TrafficShapingMode controls what happens to a call that exceeds the
+	// limits of its traffic class.
+	//
+	// NOTE: Experimental
+	TrafficShapingMode = internal.TrafficShapingMode
+
+	// LoadBalancingPolicy controls how a client spreads calls across the
+	// endpoints in ConnectionOptions.Endpoints or ConnectionOptions.SRVName.
+	//
+	// NOTE: Experimental
+	LoadBalancingPolicy = internal.LoadBalancingPolicy
+
 	// Credentials are optional credentials that can be specified in ClientOptions.
 	Credentials = internal.Credentials
 
@@ -283,6 +369,43 @@ type (
 	// ScheduleSpec describes when a schedules action should occur.
 	ScheduleSpec = internal.ScheduleSpec
 
+	// ScheduleSpecEvaluator computes the times matched by a ScheduleSpec locally. Create with
+	// [NewScheduleSpecEvaluator].
+	//
+	// NOTE: Experimental
+	ScheduleSpecEvaluator = internal.ScheduleSpecEvaluator
+
+	// ScheduleReconcilerOptions are the options for [NewScheduleReconciler].
+	//
+	// NOTE: Experimental
+	ScheduleReconcilerOptions = internal.ScheduleReconcilerOptions
+
+	// ScheduleReconciler keeps schedules in sync with a desired set of ScheduleOptions. Create with
+	// [NewScheduleReconciler].
+	//
+	// NOTE: Experimental
+	ScheduleReconciler = internal.ScheduleReconciler
+
+	// SchedulePlan is the set of changes that reconciles schedules with their desired options.
+	//
+	// NOTE: Experimental
+	SchedulePlan = internal.SchedulePlan
+
+	// ScheduleChange is the change a SchedulePlan makes to one schedule.
+	//
+	// NOTE: Experimental
+	ScheduleChange = internal.ScheduleChange
+
+	// ScheduleFieldDiff is a field of a schedule that differs from its desired value.
+	//
+	// NOTE: Experimental
+	ScheduleFieldDiff = internal.ScheduleFieldDiff
+
+	// ScheduleChangeKind is the kind of change a SchedulePlan makes to a schedule.
+	//
+	// NOTE: Experimental
+	ScheduleChangeKind = internal.ScheduleChangeKind
+
 	// SchedulePolicies describes the current polcies of a schedule.
 	SchedulePolicies = internal.SchedulePolicies
 
@@ -934,6 +1057,38 @@ type (
 	// NOTE: Experimental
 	ListActivitiesResult = internal.ClientListActivitiesResult
 
+	// ListWorkflowExecutionsOptions are the options of Client.ListWorkflowExecutions and
+	// Client.ListArchivedWorkflowExecutions.
+	//
+	// NOTE: Experimental
+	ListWorkflowExecutionsOptions = internal.ClientListWorkflowExecutionsOptions
+
+	// ListClosedWorkflowExecutionsOptions are the options of Client.ListClosedWorkflowExecutions.
+	//
+	// NOTE: Experimental
+	ListClosedWorkflowExecutionsOptions = internal.ClientListClosedWorkflowExecutionsOptions
+
+	// ListWorkflowExecutionsResult contains the result of the Client.ListWorkflowExecutions,
+	// Client.ListArchivedWorkflowExecutions and Client.ListClosedWorkflowExecutions calls.
+	//
+	// NOTE: Experimental
+	ListWorkflowExecutionsResult = internal.ClientListWorkflowExecutionsResult
+
+	// WorkflowExecutionInfo contains information about a workflow execution returned by Client.ListWorkflowExecutions,
+	// Client.ListArchivedWorkflowExecutions and Client.ListClosedWorkflowExecutions. Memo values are decoded with its
+	// GetMemoValue method.
+	//
+	// NOTE: Experimental
+	WorkflowExecutionInfo = internal.ClientWorkflowExecutionInfo
+
+	// VisibilityQuery is a filter of a visibility query built from typed search attribute keys, such as
+	// temporal.SearchAttributeKeyKeyword.Equal. Set it as the TypedQuery of ListWorkflowExecutionsOptions,
+	// ListActivitiesOptions, CountActivitiesOptions or ScheduleListOptions, or pass the escaped query rendered by its
+	// String method to ListWorkflow or CountWorkflow. The zero value matches everything.
+	//
+	// NOTE: Experimental
+	VisibilityQuery = internal.VisibilityQuery
+
 	// CountActivitiesOptions contains input for CountActivities call.
 	//
 	// NOTE: Experimental
@@ -1256,6 +1411,8 @@ type (
 		//  - "(WorkflowId = 'wid1' or (WorkflowType = 'type2' and WorkflowId = 'wid2'))".
 		//  - "CloseTime between '2019-08-27T15:04:05+00:00' and '2019-08-28T15:04:05+00:00'".
 		//  - to list only open workflow use "CloseTime is null"
+		// Queries can also be built from typed search attribute keys with VisibilityQuery and passed rendered with its
+		// String method, which escapes the values.
 		// For supported operations on different server versions see https://docs.temporal.io/visibility.
 		// Retrieved workflow executions are sorted by StartTime in descending order when list open workflow,
 		// and sorted by CloseTime in descending order for other queries.
@@ -1275,6 +1432,25 @@ type (
 		//  - serviceerror.Unavailable
 		ListArchivedWorkflow(ctx context.Context, request *workflowservice.ListArchivedWorkflowExecutionsRequest) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error)
 
+		// ListWorkflowExecutions lists the workflow executions matching the query of the options. Iterating over the
+		// Results fetches the pages from the server as needed like ListWorkflow does.
+		//
+		// NOTE: Experimental
+		ListWorkflowExecutions(ctx context.Context, options ListWorkflowExecutionsOptions) (ListWorkflowExecutionsResult, error)
+
+		// ListArchivedWorkflowExecutions lists the archived workflow executions matching the query of the options.
+		// Iterating over the Results fetches the pages from the server as needed like ListArchivedWorkflow does.
+		//
+		// NOTE: Experimental
+		ListArchivedWorkflowExecutions(ctx context.Context, options ListWorkflowExecutionsOptions) (ListWorkflowExecutionsResult, error)
+
+		// ListClosedWorkflowExecutions lists the closed workflow executions matching the filters of the options.
+		// Iterating over the Results fetches the pages from the server as needed like ListClosedWorkflow does. It
+		// returns an error if more than one of the WorkflowID, WorkflowType and Status filters is set.
+		//
+		// NOTE: Experimental
+		ListClosedWorkflowExecutions(ctx context.Context, options ListClosedWorkflowExecutionsOptions) (ListWorkflowExecutionsResult, error)
+
 		// ScanWorkflow gets workflow executions based on query. The query is basically the SQL WHERE clause
 		// (see ListWorkflow for query examples).
 		// For supported operations on different server versions see https://docs.temporal.io/visibility.
@@ -1586,6 +1762,27 @@ type MetricsTimer = metrics.Timer
 // MetricsNopHandler is a noop handler that does nothing with the metrics.
 var MetricsNopHandler = metrics.NopHandler
 
+// MetricsCardinalityOptions configures the handler returned by
+// NewMetricsCardinalityHandler.
+//
+// NOTE: Experimental
+type MetricsCardinalityOptions = metrics.CardinalityOptions
+
+// MetricsCardinalityRule configures how a metric's name and tags are
+// rewritten.
+//
+// NOTE: Experimental
+type MetricsCardinalityRule = metrics.CardinalityRule
+
+// NewMetricsCardinalityHandler wraps a metrics handler to drop or allowlist
+// tags per metric, replace tag values past a cardinality limit with "other",
+// and rename metrics. It works with any MetricsHandler implementation.
+//
+// NOTE: Experimental
+func NewMetricsCardinalityHandler(underlying MetricsHandler, options MetricsCardinalityOptions) MetricsHandler {
+	return metrics.NewCardinalityHandler(underlying, options)
+}
+
 // Dial creates an instance of a workflow client. This will attempt to connect
 // to the server eagerly and will return an error if the server is not
 // available.
@@ -1684,6 +1881,21 @@ func NewValues(data *commonpb.Payloads) converter.EncodedValues {
 	return internal.NewValues(data)
 }
 
+// NewScheduleSpecEvaluator validates a schedule spec and creates an evaluator that computes the times it matches
+// locally, without a server.
+//
+// NOTE: Experimental
+func NewScheduleSpecEvaluator(spec ScheduleSpec) (*ScheduleSpecEvaluator, error) {
+	return internal.NewScheduleSpecEvaluator(spec)
+}
+
+// NewScheduleReconciler creates a reconciler for the schedules of the client, usually [Client.ScheduleClient].
+//
+// NOTE: Experimental
+func NewScheduleReconciler(client ScheduleClient, options ScheduleReconcilerOptions) (*ScheduleReconciler, error) {
+	return internal.NewScheduleReconciler(client, options)
+}
+
 // HistoryJSONOptions are options for HistoryFromJSON.
 type HistoryJSONOptions struct {
 	// LastEventID, if set, will only load history up to this ID (inclusive).
@@ -0,0 +1,192 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.temporal.io/sdk/log"
+)
+
+// DefaultLogAttributeKeys maps the standard Temporal logging tags to the
+// attribute keys set on OpenTelemetry log records by the Logger.
+var DefaultLogAttributeKeys = map[string]string{
+	"Namespace":    "temporal.namespace",
+	"TaskQueue":    "temporal.task_queue",
+	"WorkflowType": "temporal.workflow.type",
+	"WorkflowID":   "temporal.workflow.id",
+	"RunID":        "temporal.workflow.run_id",
+	"ActivityType": "temporal.activity.type",
+	"ActivityID":   "temporal.activity.id",
+	"Attempt":      "temporal.attempt",
+}
+
+// LoggerOptions are options provided to NewLogger.
+type LoggerOptions struct {
+	// LoggerProvider is used to obtain the OpenTelemetry logger records are
+	// emitted to.
+	//
+	// Optional: Defaults to the global logger provider.
+	LoggerProvider otellog.LoggerProvider
+
+	// Name is the instrumentation scope name of the OpenTelemetry logger.
+	//
+	// Optional: Defaults to "temporal-sdk-go".
+	Name string
+
+	// AttributeKeys maps Temporal logging tag keys to the attribute keys they
+	// are emitted with. Entries are merged on top of DefaultLogAttributeKeys.
+	// Tags not present in either are emitted with their key unchanged.
+	AttributeKeys map[string]string
+}
+
+// Logger is an implementation of log.Logger that emits OpenTelemetry log
+// records. When used with the tracing interceptor from NewTracingInterceptor,
+// records logged through the workflow and activity loggers carry the trace and
+// span ID of the current workflow or activity span.
+type Logger struct {
+	logger        otellog.Logger
+	attributeKeys map[string]string
+	attrs         []otellog.KeyValue
+	spanContext   trace.SpanContext
+}
+
+var _ log.Logger = (*Logger)(nil)
+var _ log.WithLogger = (*Logger)(nil)
+
+// NewLogger creates a Logger with the given options.
+func NewLogger(options LoggerOptions) *Logger {
+	if options.LoggerProvider == nil {
+		options.LoggerProvider = global.GetLoggerProvider()
+	}
+	if options.Name == "" {
+		options.Name = "temporal-sdk-go"
+	}
+	attributeKeys := make(map[string]string, len(DefaultLogAttributeKeys)+len(options.AttributeKeys))
+	for k, v := range DefaultLogAttributeKeys {
+		attributeKeys[k] = v
+	}
+	for k, v := range options.AttributeKeys {
+		attributeKeys[k] = v
+	}
+	return &Logger{
+		logger:        options.LoggerProvider.Logger(options.Name),
+		attributeKeys: attributeKeys,
+	}
+}
+
+// Debug implements log.Logger.Debug.
+func (l *Logger) Debug(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityDebug, "DEBUG", msg, keyvals)
+}
+
+// Info implements log.Logger.Info.
+func (l *Logger) Info(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityInfo, "INFO", msg, keyvals)
+}
+
+// Warn implements log.Logger.Warn.
+func (l *Logger) Warn(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityWarn, "WARN", msg, keyvals)
+}
+
+// Error implements log.Logger.Error.
+func (l *Logger) Error(msg string, keyvals ...interface{}) {
+	l.emit(otellog.SeverityError, "ERROR", msg, keyvals)
+}
+
+// With implements log.WithLogger.With.
+func (l *Logger) With(keyvals ...interface{}) log.Logger {
+	ret := &Logger{
+		logger:        l.logger,
+		attributeKeys: l.attributeKeys,
+		attrs:         make([]otellog.KeyValue, len(l.attrs), len(l.attrs)+len(keyvals)/2),
+		spanContext:   l.spanContext,
+	}
+	copy(ret.attrs, l.attrs)
+	ret.attrs, ret.spanContext = ret.appendKeyvals(ret.attrs, ret.spanContext, keyvals)
+	return ret
+}
+
+func (l *Logger) emit(severity otellog.Severity, severityText, msg string, keyvals []interface{}) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, otellog.EnabledParameters{Severity: severity}) {
+		return
+	}
+	attrs, spanContext := l.appendKeyvals(l.attrs, l.spanContext, keyvals)
+	if spanContext.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, spanContext)
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(severity)
+	record.SetSeverityText(severityText)
+	record.SetBody(otellog.StringValue(msg))
+	record.AddAttributes(attrs...)
+	l.logger.Emit(ctx, record)
+}
+
+// appendKeyvals converts the key-value pairs to attributes. The trace and span
+// IDs set by the tracer's GetLogger are used for the span context instead.
+func (l *Logger) appendKeyvals(
+	attrs []otellog.KeyValue,
+	spanContext trace.SpanContext,
+	keyvals []interface{},
+) ([]otellog.KeyValue, trace.SpanContext) {
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var value interface{}
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		switch v := value.(type) {
+		case trace.TraceID:
+			spanContext = spanContext.WithTraceID(v)
+			continue
+		case trace.SpanID:
+			spanContext = spanContext.WithSpanID(v)
+			continue
+		}
+		if mapped, ok := l.attributeKeys[key]; ok {
+			key = mapped
+		}
+		attrs = append(attrs, otellog.KeyValue{Key: key, Value: logValue(value)})
+	}
+	return attrs, spanContext
+}
+
+func logValue(value interface{}) otellog.Value {
+	switch v := value.(type) {
+	case nil:
+		return otellog.Value{}
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case int:
+		return otellog.IntValue(v)
+	case int32:
+		return otellog.Int64Value(int64(v))
+	case int64:
+		return otellog.Int64Value(v)
+	case float64:
+		return otellog.Float64Value(v)
+	case []byte:
+		return otellog.BytesValue(v)
+	case time.Duration:
+		return otellog.StringValue(v.String())
+	case time.Time:
+		return otellog.StringValue(v.Format(time.RFC3339Nano))
+	case error:
+		return otellog.StringValue(v.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(v.String())
+	default:
+		return otellog.StringValue(fmt.Sprint(v))
+	}
+}
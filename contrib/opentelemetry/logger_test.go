@@ -0,0 +1,118 @@
+package opentelemetry_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+type memoryLogExporter struct {
+	lock    sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryLogExporter) Shutdown(context.Context) error { return nil }
+
+func (e *memoryLogExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *memoryLogExporter) find(body string) *sdklog.Record {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for i := range e.records {
+		if e.records[i].Body().AsString() == body {
+			return &e.records[i]
+		}
+	}
+	return nil
+}
+
+func recordAttributes(r *sdklog.Record) map[string]string {
+	attrs := map[string]string{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	return attrs
+}
+
+func TestLogger(t *testing.T) {
+	var rec tracetest.SpanRecorder
+	tracer, err := opentelemetry.NewTracer(opentelemetry.TracerOptions{
+		Tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(&rec)).Tracer(""),
+	})
+	require.NoError(t, err)
+
+	var exporter memoryLogExporter
+	logger := opentelemetry.NewLogger(opentelemetry.LoggerOptions{
+		LoggerProvider: sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(&exporter))),
+		AttributeKeys:  map[string]string{"Namespace": "ns"},
+	})
+
+	var suite testsuite.WorkflowTestSuite
+	suite.SetLogger(logger)
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(testActivity)
+	env.RegisterWorkflow(testWorkflow)
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{interceptor.NewTracingInterceptor(tracer)},
+	})
+	env.ExecuteWorkflow(testWorkflow)
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range rec.Ended() {
+		spans[span.Name()] = span
+	}
+
+	// Workflow log
+	r := exporter.find("inside a worflow")
+	require.NotNil(t, r)
+	require.Equal(t, otellog.SeverityInfo, r.Severity())
+	workflowSpan := spans["RunWorkflow:testWorkflow"].SpanContext()
+	require.Equal(t, workflowSpan.TraceID(), r.TraceID())
+	require.Equal(t, workflowSpan.SpanID(), r.SpanID())
+	require.NotContains(t, recordAttributes(r), "TraceID")
+
+	// Activity log
+	r = exporter.find("inside an activity")
+	require.NotNil(t, r)
+	activitySpan := spans["RunActivity:testActivity"].SpanContext()
+	require.Equal(t, activitySpan.TraceID(), r.TraceID())
+	require.Equal(t, activitySpan.SpanID(), r.SpanID())
+	require.Equal(t, "testActivity", recordAttributes(r)["temporal.activity.type"])
+
+	// Tag mapping
+	log.With(logger, "WorkflowID", "wf-id", "RunID", "run-id").Warn("tags", "Namespace", "my-ns", "Other", 5)
+	r = exporter.find("tags")
+	require.NotNil(t, r)
+	require.Equal(t, otellog.SeverityWarn, r.Severity())
+	require.False(t, r.TraceID().IsValid())
+	require.Equal(t, map[string]string{
+		"temporal.workflow.id":     "wf-id",
+		"temporal.workflow.run_id": "run-id",
+		"ns":                       "my-ns",
+		"Other":                    "5",
+	}, recordAttributes(r))
+}
@@ -43,6 +43,18 @@ type TracerOptions struct {
 	// DisableBaggage can be set to disable baggage propagation.
 	DisableBaggage bool
 
+	// BaggageKeys, if non-empty, is the allowlist of baggage member keys
+	// propagated through Temporal headers. Other members are dropped. If empty,
+	// all baggage is propagated unless DisableBaggage is set.
+	BaggageKeys []string
+
+	// SpanLinks can be set to have workflow runs started by continue-as-new or
+	// by a schedule begin a new trace with a span link to the previous run or
+	// schedule creation, instead of continuing the same trace as a child. This
+	// keeps traces of long-running workflows bounded. Baggage is carried over
+	// the link.
+	SpanLinks bool
+
 	// AllowInvalidParentSpans will swallow errors interpreting parent
 	// spans from headers. Useful when migrating from one tracing library
 	// to another, while workflows/activities may be in progress.
@@ -74,7 +86,8 @@ const defaultHeaderKey = "_tracer-data"
 
 type tracer struct {
 	interceptor.BaseTracer
-	options *TracerOptions
+	options     *TracerOptions
+	baggageKeys map[string]bool
 }
 
 // NewTracer creates a tracer with the given options. Most callers should use
@@ -103,7 +116,14 @@ func NewTracer(options TracerOptions) (interceptor.Tracer, error) {
 			return span
 		}
 	}
-	return &tracer{options: &options}, nil
+	t := &tracer{options: &options}
+	if len(options.BaggageKeys) > 0 {
+		t.baggageKeys = make(map[string]bool, len(options.BaggageKeys))
+		for _, k := range options.BaggageKeys {
+			t.baggageKeys[k] = true
+		}
+	}
+	return t, nil
 }
 
 // NewTracingInterceptor creates an interceptor for setting on client options
@@ -124,6 +144,7 @@ func (t *tracer) Options() interceptor.TracerOptions {
 		DisableQueryTracing:     t.options.DisableQueryTracing,
 		DisableUpdateTracing:    t.options.DisableUpdateTracing,
 		AllowInvalidParentSpans: t.options.AllowInvalidParentSpans,
+		SpanLinks:               t.options.SpanLinks,
 	}
 }
 
@@ -140,7 +161,7 @@ func (t *tracer) UnmarshalSpan(m map[string]string) (interceptor.TracerSpanRef,
 	}
 	spanRef := &tracerSpanRef{SpanContext: spanCtx}
 	if !t.options.DisableBaggage {
-		spanRef.Baggage = baggage.FromContext(ctx)
+		spanRef.Baggage = t.filterBaggage(baggage.FromContext(ctx))
 	}
 	return spanRef, nil
 }
@@ -150,7 +171,7 @@ func (t *tracer) MarshalSpan(span interceptor.TracerSpan) (map[string]string, er
 	tSpan := span.(*tracerSpan)
 	ctx := context.Background()
 	if !t.options.DisableBaggage {
-		ctx = baggage.ContextWithBaggage(ctx, tSpan.Baggage)
+		ctx = baggage.ContextWithBaggage(ctx, t.filterBaggage(tSpan.Baggage))
 	}
 	t.options.TextMapPropagator.Inject(trace.ContextWithSpan(ctx, tSpan.Span), data)
 	return data, nil
@@ -205,12 +226,34 @@ func (t *tracer) StartSpan(opts *interceptor.TracerStartSpanOptions) (intercepto
 	default:
 		return nil, fmt.Errorf("unrecognized parent type %T", optParent)
 	}
+	// Links are only related spans, but baggage carries over them when there is
+	// no parent
+	var links []trace.Link
+	for _, ref := range opts.Links {
+		var link trace.SpanContext
+		var linkBag baggage.Baggage
+		switch optLink := ref.(type) {
+		case *tracerSpan:
+			link, linkBag = optLink.SpanContext(), optLink.Baggage
+		case *tracerSpanRef:
+			link, linkBag = optLink.SpanContext, optLink.Baggage
+		default:
+			return nil, fmt.Errorf("unrecognized link type %T", optLink)
+		}
+		if !link.IsValid() {
+			continue
+		}
+		links = append(links, trace.Link{SpanContext: link})
+		if !parent.IsValid() && bag.Len() == 0 {
+			bag = linkBag
+		}
+	}
 	ctx := context.Background()
 	if parent.IsValid() {
 		ctx = trace.ContextWithSpanContext(ctx, parent)
-		if !t.options.DisableBaggage {
-			ctx = baggage.ContextWithBaggage(ctx, bag)
-		}
+	}
+	if !t.options.DisableBaggage {
+		ctx = baggage.ContextWithBaggage(ctx, bag)
 	}
 
 	if opts.ToHeader && opts.FromHeader {
@@ -223,7 +266,11 @@ func (t *tracer) StartSpan(opts *interceptor.TracerStartSpanOptions) (intercepto
 	}
 
 	// Create span
-	span := t.options.SpanStarter(ctx, t.options.Tracer, opts.Operation+":"+opts.Name, trace.WithTimestamp(opts.Time), trace.WithSpanKind(spanKind))
+	startOpts := []trace.SpanStartOption{trace.WithTimestamp(opts.Time), trace.WithSpanKind(spanKind)}
+	if len(links) > 0 {
+		startOpts = append(startOpts, trace.WithLinks(links...))
+	}
+	span := t.options.SpanStarter(ctx, t.options.Tracer, t.SpanName(opts), startOpts...)
 
 	// Set tags
 	if len(opts.Tags) > 0 {
@@ -242,12 +289,27 @@ func (t *tracer) StartSpan(opts *interceptor.TracerStartSpanOptions) (intercepto
 	return tSpan, nil
 }
 
+// filterBaggage returns the baggage with only members in BaggageKeys, if set.
+func (t *tracer) filterBaggage(bag baggage.Baggage) baggage.Baggage {
+	if t.baggageKeys == nil {
+		return bag
+	}
+	for _, member := range bag.Members() {
+		if !t.baggageKeys[member.Key()] {
+			bag = bag.DeleteMember(member.Key())
+		}
+	}
+	return bag
+}
+
 func (t *tracer) GetLogger(logger log.Logger, ref interceptor.TracerSpanRef) log.Logger {
 	span, ok := ref.(*tracerSpan)
 	if !ok {
 		return logger
 	}
 
+	// Logger recognizes these typed values and sets them on the record's span
+	// context instead of as attributes
 	logger = log.With(logger,
 		"TraceID", span.SpanContext().TraceID(),
 		"SpanID", span.SpanContext().SpanID(),
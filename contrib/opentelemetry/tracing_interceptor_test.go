@@ -9,12 +9,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
+	commonpb "go.temporal.io/api/common/v1"
 
 	"go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/internal/interceptortest"
 	"go.temporal.io/sdk/temporal"
@@ -235,3 +239,83 @@ func TestSpanFromWorkflowContextNoOpSpan(t *testing.T) {
 	require.True(t, env.IsWorkflowCompleted())
 	require.NoError(t, env.GetWorkflowError())
 }
+
+func continueAsNewWorkflow(ctx workflow.Context, runs int) ([]string, error) {
+	if runs > 0 {
+		return nil, workflow.NewContinueAsNewError(ctx, continueAsNewWorkflow, runs-1)
+	}
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: 10 * time.Second})
+	var ret []string
+	err := workflow.ExecuteActivity(ctx, baggageActivity).Get(ctx, &ret)
+	return ret, err
+}
+
+func baggageActivity(ctx context.Context) ([]string, error) {
+	bag := baggage.FromContext(ctx)
+	return []string{bag.Member("tenant").Value(), bag.Member("secret").Value()}, nil
+}
+
+func TestContinueAsNewSpanLinks(t *testing.T) {
+	var rec tracetest.SpanRecorder
+	tracer, err := opentelemetry.NewTracer(opentelemetry.TracerOptions{
+		Tracer:      sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(&rec)).Tracer(""),
+		SpanLinks:   true,
+		BaggageKeys: []string{"tenant"},
+	})
+	require.NoError(t, err)
+
+	// Start the first run with a client span and baggage on the header
+	tenant, err := baggage.NewMember("tenant", "tenant1")
+	require.NoError(t, err)
+	secret, err := baggage.NewMember("secret", "s3cret")
+	require.NoError(t, err)
+	bag, err := baggage.New(tenant, secret)
+	require.NoError(t, err)
+	ctx, clientSpan := sdktrace.NewTracerProvider().Tracer("").Start(baggage.ContextWithBaggage(context.Background(), bag), "client")
+	carrier := propagation.MapCarrier{}
+	opentelemetry.DefaultTextMapPropagator.Inject(ctx, carrier)
+	clientSpan.End()
+	payload, err := converter.GetDefaultDataConverter().ToPayload(map[string]string(carrier))
+	require.NoError(t, err)
+
+	runWorkflow := func(header *commonpb.Header, runs int) *testsuite.TestWorkflowEnvironment {
+		var suite testsuite.WorkflowTestSuite
+		env := suite.NewTestWorkflowEnvironment()
+		env.RegisterWorkflow(continueAsNewWorkflow)
+		env.RegisterActivity(baggageActivity)
+		env.SetWorkerOptions(worker.Options{
+			Interceptors: []interceptor.WorkerInterceptor{interceptor.NewTracingInterceptor(tracer)},
+		})
+		env.SetHeader(header)
+		env.ExecuteWorkflow(continueAsNewWorkflow, runs)
+		require.True(t, env.IsWorkflowCompleted())
+		return env
+	}
+
+	// First run continues as new with the span as a link, not a parent
+	env := runWorkflow(&commonpb.Header{Fields: map[string]*commonpb.Payload{"_tracer-data": payload}}, 1)
+	var canErr *workflow.ContinueAsNewError
+	require.ErrorAs(t, env.GetWorkflowError(), &canErr)
+	require.Contains(t, canErr.Header.GetFields(), "_tracer-link")
+	require.NotContains(t, canErr.Header.GetFields(), "_tracer-data")
+
+	// Second run starts a new trace linked to the first
+	env = runWorkflow(canErr.Header, 0)
+	require.NoError(t, env.GetWorkflowError())
+	var result []string
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, []string{"tenant1", ""}, result)
+
+	var runSpans []sdktrace.ReadOnlySpan
+	for _, s := range rec.Ended() {
+		if s.Name() == "RunWorkflow:continueAsNewWorkflow" {
+			runSpans = append(runSpans, s)
+		}
+	}
+	require.Len(t, runSpans, 2)
+	require.Equal(t, clientSpan.SpanContext().TraceID(), runSpans[0].SpanContext().TraceID())
+	require.NotEqual(t, runSpans[0].SpanContext().TraceID(), runSpans[1].SpanContext().TraceID())
+	require.False(t, runSpans[1].Parent().IsValid())
+	require.Len(t, runSpans[1].Links(), 1)
+	require.Equal(t, runSpans[0].SpanContext().SpanID(), runSpans[1].Links()[0].SpanContext.SpanID())
+}
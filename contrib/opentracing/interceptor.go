@@ -156,7 +156,7 @@ func (t *tracer) StartSpan(opts *interceptor.TracerStartSpanOptions) (intercepto
 	}
 
 	// Start
-	return &tracerSpan{Span: t.options.SpanStarter(t.options.Tracer, opts.Operation+":"+opts.Name, startOpts...)}, nil
+	return &tracerSpan{Span: t.options.SpanStarter(t.options.Tracer, t.SpanName(opts), startOpts...)}, nil
 }
 
 type tracerSpanRef struct{ opentracing.SpanContext }
@@ -0,0 +1,335 @@
+// Package prometheus implements a MetricsHandler backed by
+// [github.com/prometheus/client_golang] collectors, without requiring tally.
+package prometheus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+var _ client.MetricsHandler = (*MetricsHandler)(nil)
+
+// DefaultHistogramBuckets are the buckets, in seconds, used for timers that do
+// not have buckets configured in MetricsHandlerOptions.HistogramBuckets.
+var DefaultHistogramBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// MetricsHandlerOptions are options provided to NewMetricsHandler.
+type MetricsHandlerOptions struct {
+	// Registerer to register the handler's collector on.
+	//
+	// Optional: Defaults to prometheus.DefaultRegisterer.
+	Registerer prometheus.Registerer
+
+	// InitialTags to set on the handler.
+	//
+	// Optional: Defaults to no tags.
+	InitialTags map[string]string
+
+	// HistogramBuckets sets the buckets, in seconds, used for the timer with the
+	// given SDK metric name, for example
+	// "temporal_workflow_task_schedule_to_start_latency". The "temporal_" prefix
+	// may be omitted.
+	//
+	// Optional: Timers not present use DefaultHistogramBuckets.
+	HistogramBuckets map[string][]float64
+
+	// DefaultHistogramBuckets are the buckets, in seconds, used for timers not
+	// present in HistogramBuckets.
+	//
+	// Optional: Defaults to DefaultHistogramBuckets.
+	DefaultHistogramBuckets []float64
+
+	// TagPolicy controls how tags are converted to Prometheus labels.
+	//
+	// Optional: Defaults to only sanitizing tag keys.
+	TagPolicy TagPolicy
+
+	// DisableNamingSuffixes disables appending "_total" to counter names and
+	// "_seconds" to timer names. These suffixes follow OpenMetrics naming
+	// conventions and match the names produced by contrib/tally with
+	// NewPrometheusNamingScope, so they are cross-SDK compatible.
+	DisableNamingSuffixes bool
+
+	// OnError is called when a metric cannot be recorded, for example because
+	// the same name was used for different metric kinds.
+	//
+	// Optional: Defaults to panicking on any error.
+	OnError func(error)
+}
+
+// TagPolicy controls how metric tags are converted to Prometheus labels. Tag
+// keys are always sanitized to be valid Prometheus label names.
+type TagPolicy struct {
+	// SanitizeValues replaces every character in tag values that is not a
+	// letter, digit or underscore with an underscore, like
+	// contrib/tally.PrometheusSanitizeOptions.
+	SanitizeValues bool
+
+	// MaxValueLength truncates tag values longer than this many bytes. Zero means
+	// values are never truncated.
+	MaxValueLength int
+
+	// DropTags lists tag keys that are never turned into labels.
+	DropTags []string
+}
+
+// MetricsHandler is an implementation of client.MetricsHandler backed by
+// Prometheus client_golang collectors.
+type MetricsHandler struct {
+	collector *collector
+	labels    prometheus.Labels
+}
+
+// NewMetricsHandler returns a MetricsHandler whose metrics are collected by a
+// single collector registered on options.Registerer. Each handler created with
+// this function must use a different Registerer.
+func NewMetricsHandler(options MetricsHandlerOptions) (*MetricsHandler, error) {
+	if options.Registerer == nil {
+		options.Registerer = prometheus.DefaultRegisterer
+	}
+	if options.DefaultHistogramBuckets == nil {
+		options.DefaultHistogramBuckets = DefaultHistogramBuckets
+	}
+	if options.OnError == nil {
+		options.OnError = func(err error) { panic(err) }
+	}
+	c := &collector{
+		options:  options,
+		dropTags: make(map[string]bool, len(options.TagPolicy.DropTags)),
+		families: map[string]*family{},
+	}
+	for _, key := range options.TagPolicy.DropTags {
+		c.dropTags[key] = true
+	}
+	if err := options.Registerer.Register(c); err != nil {
+		return nil, fmt.Errorf("failed registering Temporal metrics collector: %w", err)
+	}
+	h := &MetricsHandler{collector: c, labels: prometheus.Labels{}}
+	return h.withTags(options.InitialTags), nil
+}
+
+// WithTags implements client.MetricsHandler.WithTags.
+func (m *MetricsHandler) WithTags(tags map[string]string) client.MetricsHandler {
+	return m.withTags(tags)
+}
+
+func (m *MetricsHandler) withTags(tags map[string]string) *MetricsHandler {
+	labels := make(prometheus.Labels, len(m.labels)+len(tags))
+	for k, v := range m.labels {
+		labels[k] = v
+	}
+	for k, v := range tags {
+		if m.collector.dropTags[k] {
+			continue
+		}
+		labels[sanitize(k)] = m.collector.labelValue(v)
+	}
+	return &MetricsHandler{collector: m.collector, labels: labels}
+}
+
+// Counter implements client.MetricsHandler.Counter.
+func (m *MetricsHandler) Counter(name string) client.MetricsCounter {
+	c, err := m.collector.series(name, kindCounter, m.labels)
+	if err != nil {
+		m.collector.options.OnError(err)
+		return client.MetricsNopHandler.Counter(name)
+	}
+	counter := c.(prometheus.Counter)
+	return metrics.CounterFunc(func(d int64) {
+		// Prometheus counters cannot go down
+		if d > 0 {
+			counter.Add(float64(d))
+		}
+	})
+}
+
+// Gauge implements client.MetricsHandler.Gauge.
+func (m *MetricsHandler) Gauge(name string) client.MetricsGauge {
+	g, err := m.collector.series(name, kindGauge, m.labels)
+	if err != nil {
+		m.collector.options.OnError(err)
+		return client.MetricsNopHandler.Gauge(name)
+	}
+	return metrics.GaugeFunc(g.(prometheus.Gauge).Set)
+}
+
+// Timer implements client.MetricsHandler.Timer.
+func (m *MetricsHandler) Timer(name string) client.MetricsTimer {
+	h, err := m.collector.series(name, kindTimer, m.labels)
+	if err != nil {
+		m.collector.options.OnError(err)
+		return client.MetricsNopHandler.Timer(name)
+	}
+	histogram := h.(prometheus.Histogram)
+	return metrics.TimerFunc(func(d time.Duration) {
+		histogram.Observe(d.Seconds())
+	})
+}
+
+type metricKind int
+
+const (
+	kindCounter metricKind = iota
+	kindGauge
+	kindTimer
+)
+
+func (k metricKind) String() string {
+	switch k {
+	case kindCounter:
+		return "counter"
+	case kindGauge:
+		return "gauge"
+	default:
+		return "timer"
+	}
+}
+
+// collector is an unchecked prometheus.Collector holding every series created
+// through the handler. The SDK emits the same metric with different tag sets
+// depending on where it is recorded, which fixed-label vectors cannot express.
+type collector struct {
+	options  MetricsHandlerOptions
+	dropTags map[string]bool
+
+	lock     sync.RWMutex
+	families map[string]*family
+}
+
+type family struct {
+	kind   metricKind
+	series map[string]prometheus.Collector
+}
+
+func (c *collector) Describe(chan<- *prometheus.Desc) {
+	// Intentionally empty to make this an unchecked collector
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	for _, f := range c.families {
+		for _, s := range f.series {
+			s.Collect(ch)
+		}
+	}
+}
+
+func (c *collector) series(name string, kind metricKind, labels prometheus.Labels) (prometheus.Collector, error) {
+	fullName := c.metricName(name, kind)
+	key := seriesKey(labels)
+
+	c.lock.RLock()
+	f := c.families[fullName]
+	if f != nil && f.kind == kind {
+		if s, ok := f.series[key]; ok {
+			c.lock.RUnlock()
+			return s, nil
+		}
+	}
+	c.lock.RUnlock()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	f = c.families[fullName]
+	if f == nil {
+		f = &family{kind: kind, series: map[string]prometheus.Collector{}}
+		c.families[fullName] = f
+	} else if f.kind != kind {
+		return nil, fmt.Errorf("metric %q requested as %v but already registered as %v", fullName, kind, f.kind)
+	}
+	if s, ok := f.series[key]; ok {
+		return s, nil
+	}
+
+	var s prometheus.Collector
+	switch kind {
+	case kindCounter:
+		s = prometheus.NewCounter(prometheus.CounterOpts{Name: fullName, Help: fullName, ConstLabels: labels})
+	case kindGauge:
+		s = prometheus.NewGauge(prometheus.GaugeOpts{Name: fullName, Help: fullName, ConstLabels: labels})
+	case kindTimer:
+		buckets, ok := c.options.HistogramBuckets[name]
+		if !ok {
+			buckets, ok = c.options.HistogramBuckets[strings.TrimPrefix(name, metrics.TemporalMetricsPrefix)]
+		}
+		if !ok {
+			buckets = c.options.DefaultHistogramBuckets
+		}
+		s = prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        fullName,
+			Help:        fullName,
+			ConstLabels: labels,
+			Buckets:     buckets,
+		})
+	}
+	f.series[key] = s
+	return s, nil
+}
+
+func (c *collector) metricName(name string, kind metricKind) string {
+	name = sanitize(name)
+	if c.options.DisableNamingSuffixes {
+		return name
+	}
+	switch kind {
+	case kindCounter:
+		if !strings.HasSuffix(name, "_total") {
+			name += "_total"
+		}
+	case kindTimer:
+		if !strings.HasSuffix(name, "_seconds") {
+			name += "_seconds"
+		}
+	}
+	return name
+}
+
+func (c *collector) labelValue(v string) string {
+	if c.options.TagPolicy.SanitizeValues {
+		v = sanitize(v)
+	}
+	if max := c.options.TagPolicy.MaxValueLength; max > 0 && len(v) > max {
+		v = v[:max]
+	}
+	return v
+}
+
+// seriesKey returns a key uniquely identifying the label set.
+func seriesKey(labels prometheus.Labels) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(labels[k])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// sanitize replaces every character that is not a letter, digit or underscore
+// with an underscore and prefixes names starting with a digit.
+func sanitize(s string) string {
+	b := []byte(s)
+	for i, r := range b {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			b[i] = '_'
+		}
+	}
+	if len(b) > 0 && b[0] >= '0' && b[0] <= '9' {
+		return "_" + string(b)
+	}
+	return string(b)
+}
@@ -0,0 +1,126 @@
+package prometheus_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	contribprometheus "go.temporal.io/sdk/contrib/prometheus"
+)
+
+func gather(t *testing.T, registry *prometheus.Registry) []string {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	var metrics []string
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			var value any
+			switch f.GetType() {
+			case dto.MetricType_COUNTER:
+				value = m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				value = m.GetGauge().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				value = m.GetHistogram().GetSampleSum()
+			}
+			metrics = append(metrics, fmt.Sprintf("%v: %v - %v", f.GetName(), labels, value))
+		}
+	}
+	sort.Strings(metrics)
+	return metrics
+}
+
+func TestPrometheus(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	handler, err := contribprometheus.NewMetricsHandler(contribprometheus.MetricsHandlerOptions{
+		Registerer: registry,
+	})
+	require.NoError(t, err)
+
+	handler.Counter("counter_foo").Inc(1)
+	handler.Gauge("gauge_foo").Update(2.0)
+	handler.Timer("timer_foo").Record(3 * time.Second)
+	subHandler := handler.WithTags(map[string]string{"tagkey1": "tagval1"})
+	subHandler.Counter("counter_foo").Inc(4)
+	subHandler.Gauge("gauge_foo").Update(5.0)
+	subHandler.Timer("timer_foo").Record(6 * time.Second)
+	subSubHandler := handler.WithTags(map[string]string{"tagkey1": "tagval2", "tagkey2": "tagval2"})
+	subSubHandler.Counter("counter_foo").Inc(7)
+	subSubHandler.Gauge("gauge_foo").Update(8.0)
+	subSubHandler.Timer("timer_foo").Record(9 * time.Second)
+	// Same series is reused
+	subHandler.Counter("counter_foo").Inc(1)
+
+	require.Equal(t, []string{
+		"counter_foo_total: map[] - 1",
+		"counter_foo_total: map[tagkey1:tagval1] - 5",
+		"counter_foo_total: map[tagkey1:tagval2 tagkey2:tagval2] - 7",
+		"gauge_foo: map[] - 2",
+		"gauge_foo: map[tagkey1:tagval1] - 5",
+		"gauge_foo: map[tagkey1:tagval2 tagkey2:tagval2] - 8",
+		"timer_foo_seconds: map[] - 3",
+		"timer_foo_seconds: map[tagkey1:tagval1] - 6",
+		"timer_foo_seconds: map[tagkey1:tagval2 tagkey2:tagval2] - 9",
+	}, gather(t, registry))
+}
+
+func TestPrometheusOptions(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	var errs []error
+	handler, err := contribprometheus.NewMetricsHandler(contribprometheus.MetricsHandlerOptions{
+		Registerer:  registry,
+		InitialTags: map[string]string{"client-name": "temporal_go"},
+		HistogramBuckets: map[string][]float64{
+			"timer_foo": {1, 10},
+			"workflow_task_schedule_to_start_latency": {1, 2, 3},
+		},
+		DisableNamingSuffixes: true,
+		TagPolicy: contribprometheus.TagPolicy{
+			SanitizeValues: true,
+			MaxValueLength: 8,
+			DropTags:       []string{"workflow_id"},
+		},
+		OnError: func(err error) { errs = append(errs, err) },
+	})
+	require.NoError(t, err)
+
+	tagged := handler.WithTags(map[string]string{
+		"activity.type": "my-activity-type",
+		"workflow_id":   "wf-1",
+	})
+	tagged.Counter("counter.foo").Inc(1)
+	tagged.Timer("timer_foo").Record(2 * time.Second)
+	tagged.Timer("timer_bar").Record(2 * time.Second)
+	tagged.Timer("temporal_workflow_task_schedule_to_start_latency").Record(2 * time.Second)
+
+	require.Equal(t, []string{
+		"counter_foo: map[activity_type:my_activ client_name:temporal] - 1",
+		"temporal_workflow_task_schedule_to_start_latency: map[activity_type:my_activ client_name:temporal] - 2",
+		"timer_bar: map[activity_type:my_activ client_name:temporal] - 2",
+		"timer_foo: map[activity_type:my_activ client_name:temporal] - 2",
+	}, gather(t, registry))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	bucketCounts := map[string]int{}
+	for _, f := range families {
+		if f.GetType() == dto.MetricType_HISTOGRAM {
+			bucketCounts[f.GetName()] = len(f.GetMetric()[0].GetHistogram().GetBucket())
+		}
+	}
+	require.Equal(t, 2, bucketCounts["timer_foo"])
+	require.Equal(t, 3, bucketCounts["temporal_workflow_task_schedule_to_start_latency"])
+	require.Equal(t, len(contribprometheus.DefaultHistogramBuckets), bucketCounts["timer_bar"])
+
+	// Mismatched kind reports an error and records nothing
+	tagged.Gauge("counter.foo").Update(1)
+	require.Len(t, errs, 1)
+}
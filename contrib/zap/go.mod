@@ -0,0 +1,18 @@
+module go.temporal.io/sdk/contrib/zap
+
+go 1.24.0
+
+require (
+	github.com/stretchr/testify v1.11.1
+	go.temporal.io/sdk v1.12.0
+	go.uber.org/zap v1.27.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace go.temporal.io/sdk => ../../
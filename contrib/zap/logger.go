@@ -0,0 +1,64 @@
+// Package zap implements a Temporal logger backed by [go.uber.org/zap].
+package zap
+
+import (
+	"fmt"
+
+	"go.temporal.io/sdk/log"
+	"go.uber.org/zap"
+)
+
+var _ log.Logger = (*logger)(nil)
+var _ log.WithLogger = (*logger)(nil)
+var _ log.WithSkipCallers = (*logger)(nil)
+
+type logger struct{ zl *zap.Logger }
+
+// NewLogger creates an adapter around the given zap logger to be passed to
+// Temporal. Caller information, if enabled on the zap logger, points to the
+// code calling the Temporal logger rather than to this adapter.
+func NewLogger(zl *zap.Logger) log.Logger {
+	return &logger{zl: zl.WithOptions(zap.AddCallerSkip(1))}
+}
+
+func (l *logger) Debug(msg string, keyvals ...interface{}) {
+	l.zl.Debug(msg, fields(keyvals)...)
+}
+
+func (l *logger) Info(msg string, keyvals ...interface{}) {
+	l.zl.Info(msg, fields(keyvals)...)
+}
+
+func (l *logger) Warn(msg string, keyvals ...interface{}) {
+	l.zl.Warn(msg, fields(keyvals)...)
+}
+
+func (l *logger) Error(msg string, keyvals ...interface{}) {
+	l.zl.Error(msg, fields(keyvals)...)
+}
+
+func (l *logger) With(keyvals ...interface{}) log.Logger {
+	return &logger{zl: l.zl.With(fields(keyvals)...)}
+}
+
+func (l *logger) WithCallerSkip(depth int) log.Logger {
+	return &logger{zl: l.zl.WithOptions(zap.AddCallerSkip(depth))}
+}
+
+// fields converts key-value pairs to zap fields. A trailing key without a
+// value is logged with a nil value.
+func fields(keyvals []interface{}) []zap.Field {
+	fields := make([]zap.Field, 0, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		var value interface{}
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		fields = append(fields, zap.Any(key, value))
+	}
+	return fields
+}
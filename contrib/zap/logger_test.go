@@ -0,0 +1,67 @@
+package zap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	contribzap "go.temporal.io/sdk/contrib/zap"
+	"go.temporal.io/sdk/log"
+)
+
+func addCallerDepth(logger log.Logger) {
+	logger.Info("calling AddCallerDepth")
+}
+
+func TestZapAdapter(t *testing.T) {
+	var buf bytes.Buffer
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.FunctionKey = "function"
+	zl := zap.New(
+		zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(&buf), zapcore.DebugLevel),
+		zap.AddCaller(),
+	)
+	logger := contribzap.NewLogger(zl)
+
+	logger.Info("info log", "Key", "Value")
+	addCallerDepth(logger)
+	addCallerDepth(log.Skip(logger, 1))
+	log.With(logger, "WithKey", "WithValue").Debug("With")
+	addCallerDepth(log.With(log.Skip(logger, 1), "WithKey", "WithValue"))
+	logger.Error("error log", "Key", 1, "Dangling")
+	require.NoError(t, zl.Sync())
+
+	var ms []map[string]any
+	for _, line := range bytes.Split(buf.Bytes(), []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(line, &m))
+		ms = append(ms, m)
+	}
+
+	const testFunction = "go.temporal.io/sdk/contrib/zap_test.TestZapAdapter"
+	expectedLogs := []map[string]any{
+		{"level": "info", "msg": "info log", "Key": "Value", "function": testFunction},
+		{"level": "info", "msg": "calling AddCallerDepth", "function": "go.temporal.io/sdk/contrib/zap_test.addCallerDepth"},
+		{"level": "info", "msg": "calling AddCallerDepth", "function": testFunction},
+		{"level": "debug", "msg": "With", "WithKey": "WithValue", "function": testFunction},
+		{"level": "info", "msg": "calling AddCallerDepth", "WithKey": "WithValue", "function": testFunction},
+		{"level": "error", "msg": "error log", "Key": float64(1), "Dangling": nil, "function": testFunction},
+	}
+	require.Equal(t, len(expectedLogs), len(ms))
+	for i, expectedLog := range expectedLogs {
+		actualLog := ms[i]
+		require.Contains(t, actualLog, "ts")
+		require.True(t, strings.HasPrefix(actualLog["caller"].(string), "zap/logger_test.go:"))
+		for k, v := range expectedLog {
+			require.Equal(t, v, actualLog[k], "log %d key %v", i, k)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+module go.temporal.io/sdk/contrib/zerolog
+
+go 1.24.0
+
+require (
+	github.com/rs/zerolog v1.34.0
+	github.com/stretchr/testify v1.11.1
+	go.temporal.io/sdk v1.12.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace go.temporal.io/sdk => ../../
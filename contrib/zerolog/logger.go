@@ -0,0 +1,77 @@
+// Package zerolog implements a Temporal logger backed by
+// [github.com/rs/zerolog].
+package zerolog
+
+import (
+	"github.com/rs/zerolog"
+	"go.temporal.io/sdk/log"
+)
+
+var _ log.Logger = (*logger)(nil)
+var _ log.WithLogger = (*logger)(nil)
+var _ log.WithSkipCallers = (*logger)(nil)
+
+type logger struct {
+	zl zerolog.Logger
+	// Frames to skip from this adapter to the caller, or -1 if caller
+	// information is disabled
+	depth int
+}
+
+// LoggerOptions are options provided to NewLogger.
+type LoggerOptions struct {
+	// DisableCaller disables adding the caller to every entry in zerolog's
+	// caller field.
+	DisableCaller bool
+}
+
+// NewLogger creates an adapter around the given zerolog logger to be passed to
+// Temporal. Unless disabled in options, the adapter adds the code calling the
+// Temporal logger to every entry, so zerolog's own Caller option should not be
+// set on the given logger since it would point to this adapter.
+func NewLogger(zl zerolog.Logger, options LoggerOptions) log.Logger {
+	l := &logger{zl: zl}
+	if options.DisableCaller {
+		l.depth = -1
+	}
+	return l
+}
+
+func (l *logger) Debug(msg string, keyvals ...interface{}) {
+	l.log(l.zl.Debug(), msg, keyvals)
+}
+
+func (l *logger) Info(msg string, keyvals ...interface{}) {
+	l.log(l.zl.Info(), msg, keyvals)
+}
+
+func (l *logger) Warn(msg string, keyvals ...interface{}) {
+	l.log(l.zl.Warn(), msg, keyvals)
+}
+
+func (l *logger) Error(msg string, keyvals ...interface{}) {
+	l.log(l.zl.Error(), msg, keyvals)
+}
+
+func (l *logger) log(event *zerolog.Event, msg string, keyvals []interface{}) {
+	// Nil if the level is disabled
+	if event == nil {
+		return
+	}
+	if l.depth >= 0 {
+		// Skip this function and the level method calling it
+		event = event.Caller(l.depth + 2)
+	}
+	event.Fields(keyvals).Msg(msg)
+}
+
+func (l *logger) With(keyvals ...interface{}) log.Logger {
+	return &logger{zl: l.zl.With().Fields(keyvals).Logger(), depth: l.depth}
+}
+
+func (l *logger) WithCallerSkip(depth int) log.Logger {
+	if l.depth < 0 {
+		return l
+	}
+	return &logger{zl: l.zl, depth: l.depth + depth}
+}
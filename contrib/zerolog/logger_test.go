@@ -0,0 +1,63 @@
+package zerolog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	contribzerolog "go.temporal.io/sdk/contrib/zerolog"
+	"go.temporal.io/sdk/log"
+)
+
+func addCallerDepth(logger log.Logger) {
+	logger.Info("calling AddCallerDepth")
+}
+
+func TestZerologAdapter(t *testing.T) {
+	// Marshal the caller as the function name to compare against
+	callerMarshalFunc := zerolog.CallerMarshalFunc
+	zerolog.CallerMarshalFunc = func(pc uintptr, file string, line int) string {
+		return runtime.FuncForPC(pc).Name()
+	}
+	defer func() { zerolog.CallerMarshalFunc = callerMarshalFunc }()
+
+	var buf bytes.Buffer
+	logger := contribzerolog.NewLogger(zerolog.New(&buf), contribzerolog.LoggerOptions{})
+
+	logger.Info("info log", "Key", "Value")
+	addCallerDepth(logger)
+	addCallerDepth(log.Skip(logger, 1))
+	log.With(logger, "WithKey", "WithValue").Debug("With")
+	addCallerDepth(log.With(log.Skip(logger, 1), "WithKey", "WithValue"))
+	logger.Error("error log", "Key", 1, "OtherKey", "OtherValue")
+	contribzerolog.NewLogger(zerolog.New(&buf), contribzerolog.LoggerOptions{DisableCaller: true}).Warn("no caller")
+
+	var ms []map[string]any
+	for _, line := range bytes.Split(buf.Bytes(), []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(line, &m))
+		ms = append(ms, m)
+	}
+
+	const testFunction = "go.temporal.io/sdk/contrib/zerolog_test.TestZerologAdapter"
+	expectedLogs := []map[string]any{
+		{"level": "info", "message": "info log", "Key": "Value", "caller": testFunction},
+		{"level": "info", "message": "calling AddCallerDepth", "caller": "go.temporal.io/sdk/contrib/zerolog_test.addCallerDepth"},
+		{"level": "info", "message": "calling AddCallerDepth", "caller": testFunction},
+		{"level": "debug", "message": "With", "WithKey": "WithValue", "caller": testFunction},
+		{"level": "info", "message": "calling AddCallerDepth", "WithKey": "WithValue", "caller": testFunction},
+		{"level": "error", "message": "error log", "Key": float64(1), "OtherKey": "OtherValue", "caller": testFunction},
+		{"level": "warn", "message": "no caller"},
+	}
+	require.Equal(t, len(expectedLogs), len(ms))
+	for i, expectedLog := range expectedLogs {
+		require.Equal(t, expectedLog, ms[i], "log %d", i)
+	}
+}
@@ -20,6 +20,8 @@ const (
 	runIDTagKey      = "temporalRunID"
 	activityIDTagKey = "temporalActivityID"
 	updateIDTagKey   = "temporalUpdateID"
+
+	defaultLinkHeaderKey = "_tracer-link"
 )
 
 // Tracer is an interface for tracing implementations as used by
@@ -67,6 +69,9 @@ func (BaseTracer) GetLogger(logger log.Logger, ref TracerSpanRef) log.Logger {
 	return logger
 }
 func (BaseTracer) SpanName(options *TracerStartSpanOptions) string {
+	if options.Name == "" {
+		return options.Operation
+	}
 	return fmt.Sprintf("%s:%s", options.Operation, options.Name)
 }
 
@@ -96,6 +101,17 @@ type TracerOptions struct {
 	// DisableUpdateTracing can be set to disable update tracing.
 	DisableUpdateTracing bool
 
+	// SpanLinks can be set to have runs started by continue-as-new or by a
+	// schedule link to the span that started them instead of being its child,
+	// so each run begins a new trace. The span is serialized to LinkHeaderKey
+	// instead of HeaderKey and given to the tracer in
+	// TracerStartSpanOptions.Links.
+	SpanLinks bool
+
+	// LinkHeaderKey is the key name on the Temporal header to serialize linked
+	// spans to when SpanLinks is set. Defaults to "_tracer-link".
+	LinkHeaderKey string
+
 	// AllowInvalidParentSpans will swallow errors interpreting parent
 	// spans from headers. Useful when migrating from one tracing library
 	// to another, while workflows/activities may be in progress.
@@ -126,6 +142,11 @@ type TracerStartSpanOptions struct {
 	// Tags are a set of span tags.
 	Tags map[string]string
 
+	// Links are spans this span is related to but not a child of, such as the
+	// previous run of a workflow that continued-as-new. Only set when
+	// TracerOptions.SpanLinks is set.
+	Links []TracerSpanRef
+
 	// FromHeader is used internally, not by tracer implementations, to determine
 	// whether the parent span can be retrieved from the Temporal header.
 	FromHeader bool
@@ -184,6 +205,9 @@ func NewTracingInterceptor(tracer Tracer) Interceptor {
 	} else if options.HeaderKey == "" {
 		panic("missing header key")
 	}
+	if options.SpanLinks && options.LinkHeaderKey == "" {
+		options.LinkHeaderKey = defaultLinkHeaderKey
+	}
 	return &tracingInterceptor{tracer: tracer, options: options}
 }
 
@@ -227,12 +251,20 @@ type tracingClientOutboundInterceptor struct {
 
 func (t *tracingClientOutboundInterceptor) CreateSchedule(ctx context.Context, in *ScheduleClientCreateInput) (client.ScheduleHandle, error) {
 	// Start span and write to header
+	headerWriter := t.root.headerWriter(ctx)
+	if t.root.options.SpanLinks {
+		// Scheduled runs link to this span instead of being its children
+		header := Header(ctx)
+		headerWriter = func(span TracerSpan) error {
+			return t.root.writeSpanToHeaderKey(span, header, t.root.options.LinkHeaderKey)
+		}
+	}
 	span, ctx, err := t.root.startSpanFromContext(ctx, &TracerStartSpanOptions{
 		Operation: "CreateSchedule",
 		Name:      in.Options.ID,
 		ToHeader:  true,
 		Time:      time.Now(),
-	}, t.root.headerReader(ctx), t.root.headerWriter(ctx))
+	}, t.root.headerReader(ctx), headerWriter)
 	if err != nil {
 		return nil, err
 	}
@@ -394,6 +426,106 @@ func (t *tracingClientOutboundInterceptor) UpdateWithStartWorkflow(
 	return val, err
 }
 
+func (t *tracingClientOutboundInterceptor) CancelWorkflow(ctx context.Context, in *ClientCancelWorkflowInput) error {
+	span, ctx, err := t.startWorkflowExecutionSpan(ctx, "CancelWorkflow", in.WorkflowID, in.RunID)
+	if err != nil {
+		return err
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	err = t.Next.CancelWorkflow(ctx, in)
+	finishOpts.Error = err
+	return err
+}
+
+func (t *tracingClientOutboundInterceptor) TerminateWorkflow(ctx context.Context, in *ClientTerminateWorkflowInput) error {
+	span, ctx, err := t.startWorkflowExecutionSpan(ctx, "TerminateWorkflow", in.WorkflowID, in.RunID)
+	if err != nil {
+		return err
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	err = t.Next.TerminateWorkflow(ctx, in)
+	finishOpts.Error = err
+	return err
+}
+
+func (t *tracingClientOutboundInterceptor) DescribeWorkflow(
+	ctx context.Context,
+	in *ClientDescribeWorkflowInput,
+) (*ClientDescribeWorkflowOutput, error) {
+	span, ctx, err := t.startWorkflowExecutionSpan(ctx, "DescribeWorkflow", in.WorkflowID, in.RunID)
+	if err != nil {
+		return nil, err
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	out, err := t.Next.DescribeWorkflow(ctx, in)
+	finishOpts.Error = err
+	return out, err
+}
+
+func (t *tracingClientOutboundInterceptor) ExecuteActivity(
+	ctx context.Context,
+	in *ClientExecuteActivityInput,
+) (client.ActivityHandle, error) {
+	// Start span and write to header
+	span, ctx, err := t.root.startSpanFromContext(ctx, &TracerStartSpanOptions{
+		Operation: "StartActivity",
+		Name:      in.ActivityType,
+		Tags:      map[string]string{activityIDTagKey: in.Options.ID},
+		ToHeader:  true,
+		Time:      time.Now(),
+	}, t.root.headerReader(ctx), t.root.headerWriter(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	handle, err := t.Next.ExecuteActivity(ctx, in)
+	finishOpts.Error = err
+	return handle, err
+}
+
+func (t *tracingClientOutboundInterceptor) CancelActivity(ctx context.Context, in *ClientCancelActivityInput) error {
+	// No header on this call, so the span is not propagated
+	span, ctx, err := t.root.startSpanFromContext(ctx, &TracerStartSpanOptions{
+		Operation: "CancelActivity",
+		Name:      in.ActivityID,
+		Tags:      map[string]string{activityIDTagKey: in.ActivityID, runIDTagKey: in.RunID},
+		Time:      time.Now(),
+	}, nil, nil)
+	if err != nil {
+		return err
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	err = t.Next.CancelActivity(ctx, in)
+	finishOpts.Error = err
+	return err
+}
+
+// startWorkflowExecutionSpan starts a span for a call on an existing workflow
+// execution. These calls have no header, so the span is not propagated.
+func (t *tracingClientOutboundInterceptor) startWorkflowExecutionSpan(
+	ctx context.Context,
+	operation string,
+	workflowID string,
+	runID string,
+) (TracerSpan, context.Context, error) {
+	return t.root.startSpanFromContext(ctx, &TracerStartSpanOptions{
+		Operation: operation,
+		Name:      workflowID,
+		Tags:      map[string]string{workflowIDTagKey: workflowID, runIDTagKey: runID},
+		Time:      time.Now(),
+	}, nil, nil)
+}
+
 type tracingActivityOutboundInterceptor struct {
 	ActivityOutboundInterceptorBase
 	root *tracingInterceptor
@@ -474,6 +606,17 @@ func (t *tracingWorkflowInboundInterceptor) ExecuteWorkflow(
 	ctx workflow.Context,
 	in *ExecuteWorkflowInput,
 ) (interface{}, error) {
+	// Read the span linked by a previous run or schedule, if any
+	var links []TracerSpanRef
+	if t.root.options.SpanLinks {
+		link, err := t.root.readSpanFromHeaderKey(WorkflowHeader(ctx), t.root.options.LinkHeaderKey)
+		if err != nil && !t.root.options.AllowInvalidParentSpans {
+			return nil, err
+		} else if link != nil {
+			links = append(links, link)
+		}
+	}
+
 	// Start span reading from header
 	span, ctx, err := t.root.startSpanFromWorkflowContext(ctx, &TracerStartSpanOptions{
 		Operation: "RunWorkflow",
@@ -482,6 +625,7 @@ func (t *tracingWorkflowInboundInterceptor) ExecuteWorkflow(
 			workflowIDTagKey: t.info.WorkflowExecution.ID,
 			runIDTagKey:      t.info.WorkflowExecution.RunID,
 		},
+		Links:          links,
 		FromHeader:     true,
 		Time:           t.info.WorkflowStartTime,
 		IdempotencyKey: t.newIdempotencyKey(),
@@ -750,6 +894,120 @@ func (t *tracingWorkflowOutboundInterceptor) ExecuteNexusOperation(ctx workflow.
 	return t.Next.ExecuteNexusOperation(ctx, input)
 }
 
+func (t *tracingWorkflowOutboundInterceptor) NewTimer(ctx workflow.Context, d time.Duration) workflow.Future {
+	span, ctx, futErr := t.startNonReplaySpan(ctx, "StartTimer", d.String(), false, nil)
+	if futErr != nil {
+		return futErr
+	}
+	defer span.Finish(&TracerFinishSpanOptions{})
+
+	return t.Next.NewTimer(ctx, d)
+}
+
+func (t *tracingWorkflowOutboundInterceptor) NewTimerWithOptions(
+	ctx workflow.Context,
+	d time.Duration,
+	options workflow.TimerOptions,
+) workflow.Future {
+	name := options.Summary
+	if name == "" {
+		name = d.String()
+	}
+	span, ctx, futErr := t.startNonReplaySpan(ctx, "StartTimer", name, false, nil)
+	if futErr != nil {
+		return futErr
+	}
+	defer span.Finish(&TracerFinishSpanOptions{})
+
+	return t.Next.NewTimerWithOptions(ctx, d, options)
+}
+
+func (t *tracingWorkflowOutboundInterceptor) Sleep(ctx workflow.Context, d time.Duration) error {
+	span, ctx, futErr := t.startNonReplaySpan(ctx, "Sleep", d.String(), true, nil)
+	if futErr != nil {
+		return futErr.Get(ctx, nil)
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	err := t.Next.Sleep(ctx, d)
+	finishOpts.Error = err
+	return err
+}
+
+func (t *tracingWorkflowOutboundInterceptor) Await(ctx workflow.Context, condition func() bool) error {
+	span, ctx, futErr := t.startNonReplaySpan(ctx, "Await", "", true, nil)
+	if futErr != nil {
+		return futErr.Get(ctx, nil)
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	err := t.Next.Await(ctx, condition)
+	finishOpts.Error = err
+	return err
+}
+
+func (t *tracingWorkflowOutboundInterceptor) AwaitWithTimeout(
+	ctx workflow.Context,
+	timeout time.Duration,
+	condition func() bool,
+) (bool, error) {
+	span, ctx, futErr := t.startNonReplaySpan(ctx, "Await", timeout.String(), true, nil)
+	if futErr != nil {
+		return false, futErr.Get(ctx, nil)
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	ok, err := t.Next.AwaitWithTimeout(ctx, timeout, condition)
+	finishOpts.Error = err
+	return ok, err
+}
+
+func (t *tracingWorkflowOutboundInterceptor) AwaitWithOptions(
+	ctx workflow.Context,
+	options workflow.AwaitOptions,
+	condition func() bool,
+) (bool, error) {
+	name := options.TimerOptions.Summary
+	if name == "" && options.Timeout > 0 {
+		name = options.Timeout.String()
+	}
+	span, ctx, futErr := t.startNonReplaySpan(ctx, "Await", name, true, nil)
+	if futErr != nil {
+		return false, futErr.Get(ctx, nil)
+	}
+	var finishOpts TracerFinishSpanOptions
+	defer span.Finish(&finishOpts)
+
+	ok, err := t.Next.AwaitWithOptions(ctx, options, condition)
+	finishOpts.Error = err
+	return ok, err
+}
+
+func (t *tracingWorkflowOutboundInterceptor) SideEffect(
+	ctx workflow.Context,
+	f func(ctx workflow.Context) interface{},
+) converter.EncodedValue {
+	// Side effects cannot fail, so a span that fails to start is a noop span
+	span, ctx, _ := t.startNonReplaySpan(ctx, "SideEffect", "", true, nil)
+	defer span.Finish(&TracerFinishSpanOptions{})
+
+	return t.Next.SideEffect(ctx, f)
+}
+
+func (t *tracingWorkflowOutboundInterceptor) SideEffectWithOptions(
+	ctx workflow.Context,
+	options workflow.SideEffectOptions,
+	f func(ctx workflow.Context) interface{},
+) converter.EncodedValue {
+	span, ctx, _ := t.startNonReplaySpan(ctx, "SideEffect", options.Summary, true, nil)
+	defer span.Finish(&TracerFinishSpanOptions{})
+
+	return t.Next.SideEffectWithOptions(ctx, options, f)
+}
+
 func (t *tracingWorkflowOutboundInterceptor) NewContinueAsNewError(
 	ctx workflow.Context,
 	wfn interface{},
@@ -760,7 +1018,12 @@ func (t *tracingWorkflowOutboundInterceptor) NewContinueAsNewError(
 		if contErr, _ := err.(*workflow.ContinueAsNewError); contErr != nil {
 			// Get the current span and write header
 			if span, _ := ctx.Value(t.root.options.SpanContextKey).(TracerSpan); span != nil {
-				if writeErr := t.root.writeSpanToHeader(span, WorkflowHeader(ctx)); writeErr != nil {
+				headerKey := t.root.options.HeaderKey
+				if t.root.options.SpanLinks {
+					// The next run links to this span instead of being its child
+					headerKey = t.root.options.LinkHeaderKey
+				}
+				if writeErr := t.root.writeSpanToHeaderKey(span, WorkflowHeader(ctx), headerKey); writeErr != nil {
 					return fmt.Errorf("failed writing span when creating continue as new error: %w", writeErr)
 				}
 			}
@@ -773,7 +1036,8 @@ type nopSpan struct{}
 
 func (nopSpan) Finish(*TracerFinishSpanOptions) {}
 
-// Span always returned, even in replay. futErr is non-nil on error.
+// Span always returned, even in replay. futErr is non-nil on error. The span is
+// only written to a header if headerWriter is non-nil.
 func (t *tracingWorkflowOutboundInterceptor) startNonReplaySpan(
 	ctx workflow.Context,
 	operation string,
@@ -794,7 +1058,7 @@ func (t *tracingWorkflowOutboundInterceptor) startNonReplaySpan(
 			workflowIDTagKey: info.WorkflowExecution.ID,
 			runIDTagKey:      info.WorkflowExecution.RunID,
 		},
-		ToHeader: true,
+		ToHeader: headerWriter != nil,
 		Time:     time.Now(),
 	}, t.root.workflowHeaderReader(ctx), headerWriter)
 	if err != nil {
@@ -957,8 +1221,12 @@ func (t *tracingInterceptor) nexusHeaderWriter(header nexus.Header) func(TracerS
 }
 
 func (t *tracingInterceptor) readSpanFromHeader(header map[string]*commonpb.Payload) (TracerSpanRef, error) {
+	return t.readSpanFromHeaderKey(header, t.options.HeaderKey)
+}
+
+func (t *tracingInterceptor) readSpanFromHeaderKey(header map[string]*commonpb.Payload, headerKey string) (TracerSpanRef, error) {
 	// Get from map
-	payload := header[t.options.HeaderKey]
+	payload := header[headerKey]
 	if payload == nil {
 		return nil, nil
 	}
@@ -972,6 +1240,10 @@ func (t *tracingInterceptor) readSpanFromHeader(header map[string]*commonpb.Payl
 }
 
 func (t *tracingInterceptor) writeSpanToHeader(span TracerSpan, header map[string]*commonpb.Payload) error {
+	return t.writeSpanToHeaderKey(span, header, t.options.HeaderKey)
+}
+
+func (t *tracingInterceptor) writeSpanToHeaderKey(span TracerSpan, header map[string]*commonpb.Payload, headerKey string) error {
 	// Serialize span to map
 	data, err := t.tracer.MarshalSpan(span)
 	if err != nil || len(data) == 0 {
@@ -983,7 +1255,7 @@ func (t *tracingInterceptor) writeSpanToHeader(span TracerSpan, header map[strin
 		return err
 	}
 	// Put on header
-	header[t.options.HeaderKey] = payload
+	header[headerKey] = payload
 	return nil
 }
 
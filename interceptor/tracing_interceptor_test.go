@@ -4,6 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/internal/interceptortest"
 )
@@ -60,3 +63,62 @@ func (t *testTracer) StartSpan(options *interceptor.TracerStartSpanOptions) (int
 func TestSpanTimestamps(t *testing.T) {
 	interceptortest.RunTestWorkflow(t, &testTracer{T: t})
 }
+
+type recordingTracer struct {
+	testTracer
+	spans []string
+}
+
+func (t *recordingTracer) StartSpan(options *interceptor.TracerStartSpanOptions) (interceptor.TracerSpan, error) {
+	t.spans = append(t.spans, t.SpanName(options))
+	return testSpan{}, nil
+}
+
+type nopClientOutbound struct {
+	interceptor.ClientOutboundInterceptor
+}
+
+func (nopClientOutbound) ExecuteActivity(context.Context, *interceptor.ClientExecuteActivityInput) (client.ActivityHandle, error) {
+	return nil, nil
+}
+
+func (nopClientOutbound) CancelActivity(context.Context, *interceptor.ClientCancelActivityInput) error {
+	return nil
+}
+
+func (nopClientOutbound) CancelWorkflow(context.Context, *interceptor.ClientCancelWorkflowInput) error {
+	return nil
+}
+
+func (nopClientOutbound) TerminateWorkflow(context.Context, *interceptor.ClientTerminateWorkflowInput) error {
+	return nil
+}
+
+func (nopClientOutbound) DescribeWorkflow(context.Context, *interceptor.ClientDescribeWorkflowInput) (*interceptor.ClientDescribeWorkflowOutput, error) {
+	return nil, nil
+}
+
+func TestClientSpans(t *testing.T) {
+	tracer := &recordingTracer{testTracer: testTracer{T: t}}
+	c := interceptor.NewTracingInterceptor(tracer).InterceptClient(nopClientOutbound{})
+	ctx := context.Background()
+
+	_, err := c.ExecuteActivity(ctx, &interceptor.ClientExecuteActivityInput{
+		Options:      &client.StartActivityOptions{ID: "act-id"},
+		ActivityType: "my-activity",
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.CancelActivity(ctx, &interceptor.ClientCancelActivityInput{ActivityID: "act-id"}))
+	require.NoError(t, c.CancelWorkflow(ctx, &interceptor.ClientCancelWorkflowInput{WorkflowID: "wf-id"}))
+	require.NoError(t, c.TerminateWorkflow(ctx, &interceptor.ClientTerminateWorkflowInput{WorkflowID: "wf-id"}))
+	_, err = c.DescribeWorkflow(ctx, &interceptor.ClientDescribeWorkflowInput{WorkflowID: "wf-id"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"StartActivity:my-activity",
+		"CancelActivity:act-id",
+		"CancelWorkflow:wf-id",
+		"TerminateWorkflow:wf-id",
+		"DescribeWorkflow:wf-id",
+	}, tracer.spans)
+}
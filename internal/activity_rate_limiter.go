@@ -0,0 +1,248 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.temporal.io/api/workflowservice/v1"
+	"golang.org/x/time/rate"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+type (
+	// ActivityRateLimitOptions configures rate limiting of activity executions
+	// by a key extracted from each activity task.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ActivityRateLimitOptions]
+	ActivityRateLimitOptions struct {
+		// Key extracts the rate limit key for an activity task. Tasks with an empty
+		// key are not rate limited. See ActivityRateLimitKeyByType and
+		// ActivityRateLimitKeyByHeader.
+		Key func(info ActivityInfo, header HeaderReader) string
+
+		// Limiter is the backend that throttles tasks per key. See
+		// NewActivityRateLimiter. A throttled activity gives its task slot back
+		// while it waits, so other activities can execute in the meantime, and
+		// heartbeats so it doesn't hit its heartbeat timeout. The wait still
+		// counts towards its other timeouts.
+		Limiter ActivityRateLimiter
+	}
+
+	// ActivityRateLimiter is a rate limiter backend keyed by a value extracted
+	// from activity tasks. Implementations may be local or shared across
+	// workers.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ActivityRateLimiter]
+	ActivityRateLimiter interface {
+		// Wait blocks until an activity with the given key may execute, or returns
+		// the context error once the context is done. Implementations should keep
+		// waiting until then, even if the wait will outlast the deadline of the
+		// context, so the activity times out like any other rather than failing
+		// with the error returned here.
+		Wait(ctx context.Context, key string) error
+	}
+
+	// ActivityRateLimiterOptions configures the limiter returned by
+	// NewActivityRateLimiter.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.ActivityRateLimiterOptions]
+	ActivityRateLimiterOptions struct {
+		// ActivitiesPerSecond is the rate each key is limited to, unless
+		// overridden in KeyActivitiesPerSecond. Zero means keys without an
+		// override are not limited.
+		ActivitiesPerSecond float64
+
+		// KeyActivitiesPerSecond overrides ActivitiesPerSecond for specific keys.
+		KeyActivitiesPerSecond map[string]float64
+
+		// Burst is the number of activities per key that may execute at once
+		// before the rate applies.
+		//
+		// default: 1
+		Burst int
+	}
+
+	activityRateLimiter struct {
+		options  ActivityRateLimiterOptions
+		lock     sync.Mutex
+		limiters map[string]*rate.Limiter
+		// Number of limiters left by the last sweep, see limiter
+		sweptLimiters int
+	}
+)
+
+const (
+	// activityRateLimiterMinSweep is the number of limiters below which
+	// activityRateLimiter doesn't bother sweeping refilled limiters.
+	activityRateLimiterMinSweep = 64
+
+	// activityRateLimitSlotReturnDelay is how long an activity may wait on its
+	// rate limit before it gives its slot back for the rest of the wait.
+	activityRateLimitSlotReturnDelay = 10 * time.Millisecond
+)
+
+// NewActivityRateLimiter returns an ActivityRateLimiter that limits each key
+// to a rate local to this process.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.NewActivityRateLimiter]
+func NewActivityRateLimiter(options ActivityRateLimiterOptions) ActivityRateLimiter {
+	if options.Burst <= 0 {
+		options.Burst = 1
+	}
+	return &activityRateLimiter{options: options, limiters: map[string]*rate.Limiter{}}
+}
+
+func (l *activityRateLimiter) Wait(ctx context.Context, key string) error {
+	limiter := l.limiter(key)
+	if limiter == nil {
+		return nil
+	}
+	// Unlike rate.Limiter.Wait, wait until the context is done rather than
+	// failing right away when the delay ends after the deadline, so a throttled
+	// activity times out like any other.
+	reservation := limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+// limiter returns the limiter of the given key, or nil if the key is not
+// limited.
+func (l *activityRateLimiter) limiter(key string) *rate.Limiter {
+	perSecond, ok := l.options.KeyActivitiesPerSecond[key]
+	if !ok {
+		perSecond = l.options.ActivitiesPerSecond
+	}
+	if perSecond <= 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if limiter, ok := l.limiters[key]; ok {
+		return limiter
+	}
+	// A limiter that has refilled its burst behaves like a new one, so drop those
+	// whenever the limiters have doubled since the last sweep. This bounds them
+	// by the keys that were used recently rather than all keys ever seen.
+	if len(l.limiters) >= 2*max(l.sweptLimiters, activityRateLimiterMinSweep) {
+		now := time.Now()
+		for k, limiter := range l.limiters {
+			if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+				delete(l.limiters, k)
+			}
+		}
+		l.sweptLimiters = len(l.limiters)
+	}
+	limiter := rate.NewLimiter(rate.Limit(perSecond), l.options.Burst)
+	l.limiters[key] = limiter
+	return limiter
+}
+
+// ActivityRateLimitKeyByType returns a key function for
+// ActivityRateLimitOptions that keys on the activity type.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.ActivityRateLimitKeyByType]
+func ActivityRateLimitKeyByType() func(ActivityInfo, HeaderReader) string {
+	return func(info ActivityInfo, _ HeaderReader) string {
+		return info.ActivityType.Name
+	}
+}
+
+// ActivityRateLimitKeyByHeader returns a key function for
+// ActivityRateLimitOptions that keys on the string value of the given header
+// field, decoded with the default data converter. Tasks without the header
+// are not rate limited.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.ActivityRateLimitKeyByHeader]
+func ActivityRateLimitKeyByHeader(headerKey string) func(ActivityInfo, HeaderReader) string {
+	return func(_ ActivityInfo, header HeaderReader) string {
+		payload, ok := header.Get(headerKey)
+		if !ok {
+			return ""
+		}
+		var key string
+		if err := converter.GetDefaultDataConverter().FromPayload(payload, &key); err != nil {
+			return ""
+		}
+		return key
+	}
+}
+
+// waitActivityRateLimit blocks until the activity in the given context may
+// execute per the configured rate limit. An activity that has to wait gives
+// its slot back, if it has one, so other activities can execute in the
+// meantime, and heartbeats so it doesn't time out while it waits.
+func (ath *activityTaskHandlerImpl) waitActivityRateLimit(
+	ctx context.Context,
+	t *workflowservice.PollActivityTaskQueueResponse,
+	slot *taskSlot,
+	invoker ServiceInvoker,
+	heartbeatInterval time.Duration,
+	metricsHandler metrics.Handler,
+) error {
+	if ath.activityRateLimit.Limiter == nil || ath.activityRateLimit.Key == nil {
+		return nil
+	}
+	key := ath.activityRateLimit.Key(GetActivityInfo(ctx), NewHeaderReader(t.Header))
+	if key == "" {
+		return nil
+	}
+	start := time.Now()
+	defer func() {
+		metricsHandler.Timer(metrics.ActivityRateLimitDelay).Record(time.Since(start))
+	}()
+	waitDone := make(chan error, 1)
+	go func() {
+		waitDone <- ath.activityRateLimit.Limiter.Wait(ctx, key)
+	}()
+	select {
+	case err := <-waitDone:
+		return err
+	case <-time.After(activityRateLimitSlotReturnDelay):
+	}
+
+	if slot != nil {
+		slot.release()
+	}
+	heartbeatTicker := time.NewTicker(heartbeatInterval)
+	defer heartbeatTicker.Stop()
+	for {
+		select {
+		case err := <-waitDone:
+			if err == nil && slot != nil {
+				err = slot.reacquire(ctx)
+			}
+			return err
+		case <-heartbeatTicker.C:
+			// Keep the details of the previous attempt, the activity didn't start.
+			// A cancellation requested in the response cancels ctx, ending the wait.
+			_ = invoker.Heartbeat(ctx, t.HeartbeatDetails, true)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityRateLimiter(t *testing.T) {
+	limiter := NewActivityRateLimiter(ActivityRateLimiterOptions{
+		ActivitiesPerSecond:    1,
+		KeyActivitiesPerSecond: map[string]float64{"unlimited": 0, "fast": 1000},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Burst of one is allowed immediately, then the rate applies per key
+	require.NoError(t, limiter.Wait(ctx, "tenant1"))
+	require.NoError(t, limiter.Wait(ctx, "tenant2"))
+
+	// A wait past the deadline lasts until the deadline rather than failing right away
+	waitCtx, waitCancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer waitCancel()
+	start := time.Now()
+	require.ErrorIs(t, limiter.Wait(waitCtx, "tenant1"), context.DeadlineExceeded)
+	require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// Overrides apply to specific keys
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.Wait(ctx, "unlimited"))
+	}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.Wait(ctx, "fast"))
+	}
+
+	// Unlimited keys don't keep a limiter
+	require.Len(t, limiter.(*activityRateLimiter).limiters, 3)
+}
+
+func TestActivityRateLimiterSweepsRefilledLimiters(t *testing.T) {
+	limiter := NewActivityRateLimiter(ActivityRateLimiterOptions{
+		ActivitiesPerSecond:    1000,
+		KeyActivitiesPerSecond: map[string]float64{"slow": 0.001},
+	}).(*activityRateLimiter)
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Wait(ctx, "slow"))
+	for i := 1; i < 2*activityRateLimiterMinSweep; i++ {
+		require.NoError(t, limiter.Wait(ctx, fmt.Sprintf("key%d", i)))
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// Adding a key past the threshold drops the limiters that have refilled,
+	// but keeps the ones still limiting their key
+	require.NoError(t, limiter.Wait(ctx, "new"))
+	require.Len(t, limiter.limiters, 2)
+	require.Contains(t, limiter.limiters, "slow")
+	require.Contains(t, limiter.limiters, "new")
+	require.Equal(t, 1, limiter.sweptLimiters)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"sync/atomic"
 	"time"
 
@@ -302,6 +303,8 @@ type (
 		//  - "(WorkflowId = 'wid1' or (WorkflowType = 'type2' and WorkflowId = 'wid2'))".
 		//  - "CloseTime between '2019-08-27T15:04:05+00:00' and '2019-08-28T15:04:05+00:00'".
 		//  - to list only open workflow use "CloseTime is null"
+		// Queries can also be built from typed search attribute keys with [VisibilityQuery] and passed rendered with
+		// [VisibilityQuery.String], which escapes the values.
 		// Retrieved workflow executions are sorted by StartTime in descending order when list open workflow,
 		// and sorted by CloseTime in descending order for other queries.
 		// For supported operations on different server versions see [Visibility].
@@ -323,6 +326,25 @@ type (
 		//  - serviceerror.Unavailable
 		ListArchivedWorkflow(ctx context.Context, request *workflowservice.ListArchivedWorkflowExecutionsRequest) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error)
 
+		// ListWorkflowExecutions lists the workflow executions matching the query of the options. Iterating over the
+		// Results fetches the pages from the server as needed like ListWorkflow does.
+		//
+		// NOTE: Experimental
+		ListWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error)
+
+		// ListArchivedWorkflowExecutions lists the archived workflow executions matching the query of the options.
+		// Iterating over the Results fetches the pages from the server as needed like ListArchivedWorkflow does.
+		//
+		// NOTE: Experimental
+		ListArchivedWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error)
+
+		// ListClosedWorkflowExecutions lists the closed workflow executions matching the filters of the options.
+		// Iterating over the Results fetches the pages from the server as needed like ListClosedWorkflow does. It
+		// returns an error if more than one of the WorkflowID, WorkflowType and Status filters is set.
+		//
+		// NOTE: Experimental
+		ListClosedWorkflowExecutions(ctx context.Context, options ClientListClosedWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error)
+
 		// ScanWorkflow gets workflow executions based on query. The query is basically the SQL WHERE clause
 		// (see ListWorkflow for query examples).
 		// ScanWorkflow should be used when retrieving large amount of workflows and order is not needed.
@@ -736,6 +758,13 @@ type (
 		// the gRPC interceptor chain and can be used to induce artificial failures in test scenarios.
 		TrafficController TrafficController
 
+		// Optional: TrafficShaping limits the rate and concurrency of calls made
+		// by the client by class of gRPC method. Calls are limited before being
+		// sent, so retries of a call are not limited again.
+		//
+		// NOTE: Experimental
+		TrafficShaping TrafficShapingOptions
+
 		// Interceptors to apply to some calls of the client. Earlier interceptors
 		// wrap later interceptors.
 		//
@@ -849,9 +878,65 @@ type (
 		// grpc.WithChainUnaryInterceptor.
 		DialOptions []grpc.DialOption
 
+		// Endpoints is a list of host:port addresses of the frontend service. When
+		// set, the client connects to all of them instead of ClientOptions.HostPort,
+		// checks each one periodically with the gRPC health check API used by
+		// Client.CheckHealth, and only sends calls to healthy endpoints according
+		// to LoadBalancingPolicy. If no endpoint is healthy, all of them are tried.
+		// Endpoints becoming unhealthy or healthy again are logged and reported in
+		// the temporal_client_endpoint_healthy and temporal_client_endpoint_failover
+		// metrics, and the state of the gRPC channel is reported in the
+		// temporal_client_channel_state metric. Cannot be set with SRVName.
+		//
+		// NOTE: Experimental
+		Endpoints []string
+
+		// SRVName is a DNS SRV record name, e.g. "_temporal._tcp.example.com",
+		// whose targets are used as Endpoints. The record is resolved when the
+		// client is created and again on every health check. Cannot be set with
+		// Endpoints.
+		//
+		// NOTE: Experimental
+		SRVName string
+
+		// LoadBalancingPolicy controls how calls are spread across Endpoints or
+		// the targets of SRVName.
+		//
+		// default: LoadBalancingPolicyRoundRobin
+		//
+		// NOTE: Experimental
+		LoadBalancingPolicy LoadBalancingPolicy
+
+		// EndpointHealthCheckInterval is how often each of Endpoints or the
+		// targets of SRVName is health checked. It is also the timeout of each
+		// check.
+		//
+		// default: 10s
+		//
+		// NOTE: Experimental
+		EndpointHealthCheckInterval time.Duration
+
+		// RetryBudget, if set, limits the retries made by the client across all
+		// calls on the connection, so that callers don't all retry in lockstep and
+		// add to the load of a struggling server. Retries denied by the budget are
+		// counted in the temporal_request_retry_denied metric. By default retries
+		// are only limited per call.
+		//
+		// NOTE: Experimental
+		RetryBudget *RetryBudgetOptions
+
+		// Hedging, if set, enables hedged requests for idempotent reads. See
+		// HedgingOptions for the calls that are hedged.
+		//
+		// NOTE: Experimental
+		Hedging *HedgingOptions
+
 		// Hidden for use by client overloads.
 		disableEagerConnection bool
 
+		// Hidden for overriding SRV lookups in tests.
+		lookupSRV func(ctx context.Context, name string) ([]*net.SRV, error)
+
 		// Internal atomic that, when true, will not retry internal errors like
 		// other gRPC errors. If not present during service client creation, it will
 		// be created as false. This is set to true when server capabilities are
@@ -859,6 +944,51 @@ type (
 		excludeInternalFromRetry *atomic.Bool
 	}
 
+	// RetryBudgetOptions are options for a retry budget shared by all calls on a
+	// connection. The budget is a bucket of MaxTokens tokens. Every attempt that
+	// fails with a retryable error takes one token and every successful attempt
+	// returns TokenRatio tokens. Retries are only made while more than half of
+	// the tokens remain, so under sustained failures the ratio of retries to
+	// successful calls converges on TokenRatio.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.RetryBudgetOptions]
+	RetryBudgetOptions struct {
+		// MaxTokens is the size of the bucket.
+		//
+		// default: 100
+		MaxTokens float64
+
+		// TokenRatio is the number of tokens returned by every successful attempt.
+		//
+		// default: 0.1
+		TokenRatio float64
+	}
+
+	// HedgingOptions are options for hedged requests. Calls to
+	// DescribeWorkflowExecution, GetWorkflowExecutionHistory (except long polls
+	// waiting for new events) and QueryWorkflow that haven't completed after
+	// Delay are sent again in parallel, up to MaxAttempts in total, and the
+	// first successful response is used. When a retry budget is configured,
+	// hedged attempts are only sent while the budget allows retries. Hedged
+	// attempts are counted in the temporal_request_hedge metric.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.HedgingOptions]
+	HedgingOptions struct {
+		// Delay is how long to wait for an attempt before sending the next one.
+		// Required.
+		Delay time.Duration
+
+		// MaxAttempts is the maximum number of attempts in flight for a call,
+		// including the first one.
+		//
+		// default: 2
+		MaxAttempts int
+	}
+
 	// StartWorkflowOptions configuration parameters for starting a workflow execution.
 	// The current timeout resolution implementation is in seconds and uses math.Ceil(d.Seconds()) as the duration. But is
 	// subjected to change in the future.
@@ -1256,6 +1386,14 @@ func newClient(ctx context.Context, options ClientOptions, existing Client) (Cli
 		return nil, fmt.Errorf("cannot set both TLS and TLSDisabled in ConnectionOptions")
 	}
 
+	if err := options.TrafficShaping.validate(); err != nil {
+		return nil, err
+	}
+
+	if options.ConnectionOptions.Hedging != nil && options.ConnectionOptions.Hedging.Delay <= 0 {
+		return nil, fmt.Errorf("hedging delay must be positive")
+	}
+
 	if options.Credentials != nil {
 		if err := options.Credentials.applyToOptions(&options.ConnectionOptions); err != nil {
 			return nil, err
@@ -1333,10 +1471,13 @@ func newClientPluginRoot(ctx context.Context, options ClientPluginNewClientOptio
 
 func newDialParameters(options *ClientOptions, excludeInternalFromRetry *atomic.Bool) dialParameters {
 	return dialParameters{
-		UserConnectionOptions: options.ConnectionOptions,
-		HostPort:              options.HostPort,
-		RequiredInterceptors:  requiredInterceptors(options, excludeInternalFromRetry),
-		DefaultServiceConfig:  defaultServiceConfig,
+		UserConnectionOptions:   options.ConnectionOptions,
+		HostPort:                options.HostPort,
+		RequiredInterceptors:    requiredInterceptors(options, excludeInternalFromRetry),
+		HealthCheckInterceptors: healthCheckInterceptors(options),
+		DefaultServiceConfig:    defaultServiceConfig,
+		Logger:                  options.Logger,
+		MetricsHandler:          options.MetricsHandler,
 	}
 }
 
@@ -1484,6 +1625,10 @@ func NewNamespaceClient(options ClientOptions) (NamespaceClient, error) {
 		options.HostPort = LocalHostPort
 	}
 
+	if err := options.TrafficShaping.validate(); err != nil {
+		return nil, err
+	}
+
 	connection, err := dial(newDialParameters(&options, nil))
 	if err != nil {
 		return nil, err
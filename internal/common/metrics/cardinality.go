@@ -0,0 +1,223 @@
+package metrics
+
+import "sync"
+
+// DefaultOverflowTagValue is the tag value used in place of values past a
+// cardinality limit when CardinalityOptions.OverflowTagValue is not set.
+const DefaultOverflowTagValue = "other"
+
+// CardinalityOptions declaratively configures the handler returned by
+// NewCardinalityHandler.
+type CardinalityOptions struct {
+	// Default is the rule applied to every metric. Rules in Metrics are merged on
+	// top of it.
+	Default CardinalityRule
+
+	// Metrics are rules applied to specific metrics, keyed by the name the SDK
+	// emits the metric with, for example "temporal_activity_execution_latency".
+	Metrics map[string]CardinalityRule
+
+	// OverflowTagValue replaces tag values seen after a tag has reached its
+	// limit in CardinalityRule.MaxTagValues.
+	//
+	// Optional: Defaults to DefaultOverflowTagValue.
+	OverflowTagValue string
+}
+
+// CardinalityRule configures how a metric's name and tags are rewritten.
+type CardinalityRule struct {
+	// Rename is the name the metric is emitted to the underlying handler with.
+	// Only used in CardinalityOptions.Metrics.
+	Rename string
+
+	// AllowTags, if non-empty, lists the only tag keys kept on the metric. A
+	// per-metric value replaces the default.
+	AllowTags []string
+
+	// DropTags lists tag keys removed from the metric. Per-metric and default
+	// values are combined.
+	DropTags []string
+
+	// MaxTagValues limits the number of distinct values per tag key for a
+	// metric. Once the limit is reached, unseen values are replaced with the
+	// overflow tag value. Per-metric limits replace the default limit for the
+	// same key.
+	MaxTagValues map[string]int
+}
+
+type cardinalityHandler struct {
+	underlying Handler
+	state      *cardinalityState
+	// Never changed once created
+	tags map[string]string
+	// Keyed by metric name, values are *cardinalityTarget. Rewritten tags never
+	// change for a metric once computed, since a value past the limit of a tag
+	// is never seen later.
+	targets sync.Map
+}
+
+// cardinalityTarget is the underlying handler and name a metric is emitted
+// with.
+type cardinalityTarget struct {
+	handler Handler
+	name    string
+}
+
+type cardinalityState struct {
+	// Keyed by metric name, values are *resolvedCardinalityRule
+	rules sync.Map
+
+	options CardinalityOptions
+
+	seenLock sync.Mutex
+	// Keyed by emitted metric name, then tag key
+	seen map[string]map[string]map[string]struct{}
+}
+
+type resolvedCardinalityRule struct {
+	name         string
+	allowTags    map[string]bool
+	dropTags     map[string]bool
+	maxTagValues map[string]int
+}
+
+// NewCardinalityHandler returns a handler that rewrites the names and tags of
+// metrics before passing them to the underlying handler, per the given
+// options. Only tags set through the returned handler are affected, not tags
+// already set on the underlying handler.
+func NewCardinalityHandler(underlying Handler, options CardinalityOptions) Handler {
+	if options.OverflowTagValue == "" {
+		options.OverflowTagValue = DefaultOverflowTagValue
+	}
+	return &cardinalityHandler{
+		underlying: underlying,
+		state: &cardinalityState{
+			options: options,
+			seen:    map[string]map[string]map[string]struct{}{},
+		},
+	}
+}
+
+func (c *cardinalityHandler) WithTags(tags map[string]string) Handler {
+	ret := &cardinalityHandler{underlying: c.underlying, state: c.state, tags: make(map[string]string, len(c.tags)+len(tags))}
+	for k, v := range c.tags {
+		ret.tags[k] = v
+	}
+	for k, v := range tags {
+		ret.tags[k] = v
+	}
+	return ret
+}
+
+func (c *cardinalityHandler) Counter(name string) Counter {
+	handler, name := c.handlerFor(name)
+	return handler.Counter(name)
+}
+
+func (c *cardinalityHandler) Gauge(name string) Gauge {
+	handler, name := c.handlerFor(name)
+	return handler.Gauge(name)
+}
+
+func (c *cardinalityHandler) Timer(name string) Timer {
+	handler, name := c.handlerFor(name)
+	return handler.Timer(name)
+}
+
+func (c *cardinalityHandler) Unwrap() Handler {
+	return c.underlying
+}
+
+// handlerFor returns the underlying handler with the rewritten tags for the
+// given metric, and the name to emit it with.
+func (c *cardinalityHandler) handlerFor(name string) (Handler, string) {
+	if target, ok := c.targets.Load(name); ok {
+		return target.(*cardinalityTarget).handler, target.(*cardinalityTarget).name
+	}
+	handler, emittedName := c.rewrite(name)
+	c.targets.Store(name, &cardinalityTarget{handler: handler, name: emittedName})
+	return handler, emittedName
+}
+
+func (c *cardinalityHandler) rewrite(name string) (Handler, string) {
+	rule := c.state.rule(name)
+	if len(c.tags) == 0 {
+		return c.underlying, rule.name
+	}
+	tags := make(map[string]string, len(c.tags))
+	for k, v := range c.tags {
+		if rule.dropTags[k] || (len(rule.allowTags) > 0 && !rule.allowTags[k]) {
+			continue
+		}
+		if limit, ok := rule.maxTagValues[k]; ok {
+			v = c.state.limitValue(rule.name, k, v, limit)
+		}
+		tags[k] = v
+	}
+	return c.underlying.WithTags(tags), rule.name
+}
+
+func (s *cardinalityState) rule(name string) *resolvedCardinalityRule {
+	if rule, ok := s.rules.Load(name); ok {
+		return rule.(*resolvedCardinalityRule)
+	}
+	def := s.options.Default
+	specific := s.options.Metrics[name]
+	rule := &resolvedCardinalityRule{
+		name:         name,
+		dropTags:     map[string]bool{},
+		maxTagValues: map[string]int{},
+	}
+	if specific.Rename != "" {
+		rule.name = specific.Rename
+	}
+	allowTags := def.AllowTags
+	if len(specific.AllowTags) > 0 {
+		allowTags = specific.AllowTags
+	}
+	if len(allowTags) > 0 {
+		rule.allowTags = make(map[string]bool, len(allowTags))
+		for _, k := range allowTags {
+			rule.allowTags[k] = true
+		}
+	}
+	for _, k := range def.DropTags {
+		rule.dropTags[k] = true
+	}
+	for _, k := range specific.DropTags {
+		rule.dropTags[k] = true
+	}
+	for k, v := range def.MaxTagValues {
+		rule.maxTagValues[k] = v
+	}
+	for k, v := range specific.MaxTagValues {
+		rule.maxTagValues[k] = v
+	}
+	actual, _ := s.rules.LoadOrStore(name, rule)
+	return actual.(*resolvedCardinalityRule)
+}
+
+// limitValue returns the value, or the overflow value if the value was not
+// already seen and the limit of distinct values has been reached.
+func (s *cardinalityState) limitValue(name, key, value string, limit int) string {
+	s.seenLock.Lock()
+	defer s.seenLock.Unlock()
+	byKey := s.seen[name]
+	if byKey == nil {
+		byKey = map[string]map[string]struct{}{}
+		s.seen[name] = byKey
+	}
+	values := byKey[key]
+	if values == nil {
+		values = map[string]struct{}{}
+		byKey[key] = values
+	}
+	if _, ok := values[value]; ok {
+		return value
+	}
+	if len(values) >= limit {
+		return s.options.OverflowTagValue
+	}
+	values[value] = struct{}{}
+	return value
+}
@@ -0,0 +1,90 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/internal/common/metrics"
+)
+
+func TestCardinalityHandler(t *testing.T) {
+	capture := metrics.NewCapturingHandler()
+	handler := metrics.NewCardinalityHandler(capture, metrics.CardinalityOptions{
+		Default: metrics.CardinalityRule{
+			DropTags:     []string{metrics.WorkflowTypeNameTagName},
+			MaxTagValues: map[string]int{metrics.TaskQueueTagName: 2},
+		},
+		Metrics: map[string]metrics.CardinalityRule{
+			"counter1": {
+				Rename:    "renamed_counter1",
+				AllowTags: []string{metrics.NamespaceTagName, metrics.TaskQueueTagName},
+			},
+			"counter2": {
+				MaxTagValues: map[string]int{metrics.TaskQueueTagName: 1},
+			},
+		},
+	})
+	handler = handler.WithTags(map[string]string{
+		metrics.NamespaceTagName:        "ns",
+		metrics.WorkflowTypeNameTagName: "wf",
+		metrics.ActivityTypeNameTagName: "act",
+	})
+
+	// Rename and allowlist
+	handler.WithTags(map[string]string{metrics.TaskQueueTagName: "tq1"}).Counter("counter1").Inc(1)
+	require.Len(t, capture.Counters(), 1)
+	require.Equal(t, "renamed_counter1", capture.Counters()[0].Name)
+	require.Equal(t, map[string]string{metrics.NamespaceTagName: "ns", metrics.TaskQueueTagName: "tq1"},
+		capture.Counters()[0].Tags)
+
+	// Default drop and limit
+	for _, tq := range []string{"tq1", "tq2", "tq3", "tq4", "tq1"} {
+		handler.WithTags(map[string]string{metrics.TaskQueueTagName: tq}).Timer("timer1").Record(1)
+	}
+	var taskQueues []string
+	for _, timer := range capture.Timers() {
+		require.NotContains(t, timer.Tags, metrics.WorkflowTypeNameTagName)
+		require.Equal(t, "act", timer.Tags[metrics.ActivityTypeNameTagName])
+		taskQueues = append(taskQueues, timer.Tags[metrics.TaskQueueTagName])
+	}
+	require.Equal(t, []string{"tq1", "tq2", "other"}, taskQueues)
+	require.Equal(t, int64(2), capture.Timers()[2].Count())
+
+	// Per-metric limit replaces default and values are tracked per metric
+	for _, tq := range []string{"tq3", "tq4"} {
+		handler.WithTags(map[string]string{metrics.TaskQueueTagName: tq}).Counter("counter2").Inc(1)
+	}
+	require.Len(t, capture.Counters(), 3)
+	require.Equal(t, "tq3", capture.Counters()[1].Tags[metrics.TaskQueueTagName])
+	require.Equal(t, "other", capture.Counters()[2].Tags[metrics.TaskQueueTagName])
+
+	require.Equal(t, capture, handler.(interface{ Unwrap() metrics.Handler }).Unwrap())
+}
+
+type withTagsCountingHandler struct {
+	metrics.Handler
+	withTags *int
+}
+
+func (w withTagsCountingHandler) WithTags(tags map[string]string) metrics.Handler {
+	*w.withTags++
+	return w.Handler.WithTags(tags)
+}
+
+func TestCardinalityHandlerCachesRewrittenHandler(t *testing.T) {
+	var withTags int
+	capture := metrics.NewCapturingHandler()
+	handler := metrics.NewCardinalityHandler(withTagsCountingHandler{Handler: capture, withTags: &withTags},
+		metrics.CardinalityOptions{Default: metrics.CardinalityRule{MaxTagValues: map[string]int{metrics.TaskQueueTagName: 1}}})
+	tq1 := handler.WithTags(map[string]string{metrics.TaskQueueTagName: "tq1"})
+	tq2 := handler.WithTags(map[string]string{metrics.TaskQueueTagName: "tq2"})
+	for i := 0; i < 3; i++ {
+		tq1.Counter("counter").Inc(1)
+		tq2.Counter("counter").Inc(1)
+	}
+	require.Equal(t, 2, withTags)
+	require.Len(t, capture.Counters(), 2)
+	require.Equal(t, int64(3), capture.Counters()[0].Value())
+	require.Equal(t, "other", capture.Counters()[1].Tags[metrics.TaskQueueTagName])
+	require.Equal(t, int64(3), capture.Counters()[1].Value())
+}
@@ -18,6 +18,21 @@ const (
 	WorkflowTaskExecutionFailureCounter = TemporalMetricsPrefix + "workflow_task_execution_failed"
 	WorkflowTaskNoCompletionCounter     = TemporalMetricsPrefix + "workflow_task_no_completion"
 
+	WorkflowSignalReceivedCounter  = TemporalMetricsPrefix + "workflow_signal_received"
+	WorkflowUpdateAcceptedCounter  = TemporalMetricsPrefix + "workflow_update_accepted"
+	WorkflowUpdateRejectedCounter  = TemporalMetricsPrefix + "workflow_update_rejected"
+	WorkflowUpdateCompletedCounter = TemporalMetricsPrefix + "workflow_update_completed"
+	WorkflowUpdateExecutionLatency = TemporalMetricsPrefix + "workflow_update_execution_latency"
+	WorkflowQueryHandledCounter    = TemporalMetricsPrefix + "workflow_query_handled"
+	WorkflowQueryFailedCounter     = TemporalMetricsPrefix + "workflow_query_failed"
+	WorkflowQueryExecutionLatency  = TemporalMetricsPrefix + "workflow_query_execution_latency"
+	WorkflowTimerStartedCounter    = TemporalMetricsPrefix + "workflow_timer_started"
+	WorkflowTimerFiredCounter      = TemporalMetricsPrefix + "workflow_timer_fired"
+	WorkflowTimerCanceledCounter   = TemporalMetricsPrefix + "workflow_timer_canceled"
+	WorkflowChildStartedCounter    = TemporalMetricsPrefix + "workflow_child_workflow_started"
+	WorkflowChildFailedCounter     = TemporalMetricsPrefix + "workflow_child_workflow_failed"
+	WorkflowVersionMarkerCounter   = TemporalMetricsPrefix + "workflow_version_marker_recorded"
+
 	ActivityPollNoTaskCounter             = TemporalMetricsPrefix + "activity_poll_no_task"
 	ActivityScheduleToStartLatency        = TemporalMetricsPrefix + "activity_schedule_to_start_latency"
 	ActivityExecutionFailedCounter        = TemporalMetricsPrefix + "activity_execution_failed"
@@ -25,6 +40,12 @@ const (
 	ActivityExecutionLatency              = TemporalMetricsPrefix + "activity_execution_latency"
 	ActivitySucceedEndToEndLatency        = TemporalMetricsPrefix + "activity_succeed_endtoend_latency"
 	ActivityTaskErrorCounter              = TemporalMetricsPrefix + "activity_task_error"
+	ActivityRateLimitDelay                = TemporalMetricsPrefix + "activity_rate_limit_delay"
+	ActivityExecutionAttemptCounter       = TemporalMetricsPrefix + "activity_execution_attempt"
+	ActivityHeartbeatCounter              = TemporalMetricsPrefix + "activity_heartbeat"
+	ActivityLastHeartbeatAge              = TemporalMetricsPrefix + "activity_last_heartbeat_age"
+	ActivityTimeoutBudgetUtilization      = TemporalMetricsPrefix + "activity_timeout_budget_utilization"
+	ActivityTimeoutBudgetRemaining        = TemporalMetricsPrefix + "activity_timeout_budget_remaining"
 
 	LocalActivityTotalCounter             = TemporalMetricsPrefix + "local_activity_total"
 	LocalActivityCanceledCounter          = TemporalMetricsPrefix + "local_activity_canceled" // Deprecated: Use LocalActivityExecutionCanceledCounter instead.
@@ -42,6 +63,9 @@ const (
 	WorkerTaskSlotsUsed      = TemporalMetricsPrefix + "worker_task_slots_used"
 	PollerStartCounter       = TemporalMetricsPrefix + "poller_start"
 	NumPoller                = TemporalMetricsPrefix + "num_pollers"
+	WorkerDraining           = TemporalMetricsPrefix + "worker_draining"
+	WorkerDrainInFlightTasks = TemporalMetricsPrefix + "worker_drain_in_flight_tasks"
+	WorkerLogLinesDropped    = TemporalMetricsPrefix + "worker_log_lines_dropped"
 
 	TemporalRequest                      = TemporalMetricsPrefix + "request"
 	TemporalRequestFailure               = TemporalRequest + "_failure"
@@ -51,6 +75,15 @@ const (
 	TemporalLongRequestLatency           = TemporalLongRequest + "_latency"
 	TemporalRequestResourceExhausted     = TemporalRequest + "_resource_exhausted"
 	TemporalLongRequestResourceExhausted = TemporalLongRequest + "_resource_exhausted"
+	TemporalRequestRetryDenied           = TemporalRequest + "_retry_denied"
+	TemporalRequestHedge                 = TemporalRequest + "_hedge"
+	TemporalRequestShapingDelay          = TemporalRequest + "_shaping_delay"
+	TemporalRequestShapingRejected       = TemporalRequest + "_shaping_rejected"
+	TemporalRequestShapingInFlight       = TemporalRequest + "_shaping_in_flight"
+
+	ClientChannelState     = TemporalMetricsPrefix + "client_channel_state"
+	ClientEndpointHealthy  = TemporalMetricsPrefix + "client_endpoint_healthy"
+	ClientEndpointFailover = TemporalMetricsPrefix + "client_endpoint_failover"
 
 	StickyCacheHit                 = TemporalMetricsPrefix + "sticky_cache_hit"
 	StickyCacheMiss                = TemporalMetricsPrefix + "sticky_cache_miss"
@@ -81,6 +114,11 @@ const (
 	OperationTagName        = "operation"
 	CauseTagName            = "cause"
 	RequestFailureCode      = "status_code"
+	AttemptTagName          = "attempt"
+	UtilizationTagName      = "utilization"
+	EndpointTagName         = "endpoint"
+	ChannelStateTagName     = "channel_state"
+	TrafficClassTagName     = "traffic_class"
 )
 
 // Metric tag values
@@ -30,29 +30,13 @@ func NewGRPCInterceptor(defaultHandler Handler, suffix string, disableRequestFai
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
-		handler, _ := ctx.Value(HandlerContextKey{}).(Handler)
-		if handler == nil {
-			handler = defaultHandler
-		}
+		handler := RequestHandler(ctx, defaultHandler, method, req)
 		longPoll, ok := ctx.Value(LongPollContextKey{}).(bool)
 		if !ok {
 			longPoll = false
 		}
 
-		// Only take method name after the last slash
-		operation := method[strings.LastIndex(method, "/")+1:]
-
-		// Since this interceptor can be used for clients of different name, we
-		// attempt to extract the namespace out of the request. All namespace-based
-		// requests have been confirmed to have a top-level namespace field.
-		namespace := "_unknown_"
-		if nsReq, _ := req.(interface{ GetNamespace() string }); nsReq != nil {
-			namespace = nsReq.GetNamespace()
-		}
-
 		// Capture time, record start, run, and record end
-		tags := map[string]string{OperationTagName: operation, NamespaceTagName: namespace}
-		handler = handler.WithTags(tags)
 		start := time.Now()
 		recordRequestStart(handler, longPoll, suffix)
 		err := invoker(ctx, method, req, reply, cc, opts...)
@@ -61,6 +45,27 @@ func NewGRPCInterceptor(defaultHandler Handler, suffix string, disableRequestFai
 	}
 }
 
+// RequestHandler returns the handler in the context, or the default handler if
+// none, tagged with the operation and namespace of the given gRPC call.
+func RequestHandler(ctx context.Context, defaultHandler Handler, method string, req interface{}) Handler {
+	handler, _ := ctx.Value(HandlerContextKey{}).(Handler)
+	if handler == nil {
+		handler = defaultHandler
+	}
+
+	// Only take method name after the last slash
+	operation := method[strings.LastIndex(method, "/")+1:]
+
+	// Since this can be used for clients of different name, we attempt to
+	// extract the namespace out of the request. All namespace-based requests
+	// have been confirmed to have a top-level namespace field.
+	namespace := "_unknown_"
+	if nsReq, _ := req.(interface{ GetNamespace() string }); nsReq != nil {
+		namespace = nsReq.GetNamespace()
+	}
+	return handler.WithTags(map[string]string{OperationTagName: operation, NamespaceTagName: namespace})
+}
+
 func recordRequestStart(handler Handler, longPoll bool, suffix string) {
 	// Count request
 	metric := TemporalRequest
@@ -51,6 +51,48 @@ func LocalActivityTags(workflowType, activityType string) map[string]string {
 	}
 }
 
+// AttemptTags returns a set of tags for an attempt number, bucketed to bound
+// cardinality.
+func AttemptTags(attempt int32) map[string]string {
+	var bucket string
+	switch {
+	case attempt <= 1:
+		bucket = "1"
+	case attempt <= 3:
+		bucket = strconv.Itoa(int(attempt))
+	case attempt <= 10:
+		bucket = "4-10"
+	default:
+		bucket = "11+"
+	}
+	return map[string]string{
+		AttemptTagName: bucket,
+	}
+}
+
+// TimeoutBudgetUtilizationTags returns a set of tags for the fraction of a
+// timeout that was used, bucketed by percentage.
+func TimeoutBudgetUtilizationTags(utilization float64) map[string]string {
+	var bucket string
+	switch {
+	case utilization < 0.25:
+		bucket = "0-25"
+	case utilization < 0.5:
+		bucket = "25-50"
+	case utilization < 0.75:
+		bucket = "50-75"
+	case utilization < 0.9:
+		bucket = "75-90"
+	case utilization < 1:
+		bucket = "90-100"
+	default:
+		bucket = "100+"
+	}
+	return map[string]string{
+		UtilizationTagName: bucket,
+	}
+}
+
 // NexusTags returns a set of tags for Nexus Operations.
 func NexusTags(service, operation, taskQueueName string) map[string]string {
 	return map[string]string{
@@ -95,6 +137,27 @@ func WorkflowTaskFailedTags(reason string) map[string]string {
 	}
 }
 
+// EndpointTags returns a set of tags for a server endpoint.
+func EndpointTags(endpoint string) map[string]string {
+	return map[string]string{
+		EndpointTagName: endpoint,
+	}
+}
+
+// ChannelStateTags returns a set of tags for a gRPC channel state.
+func ChannelStateTags(state string) map[string]string {
+	return map[string]string{
+		ChannelStateTagName: state,
+	}
+}
+
+// TrafficClassTags returns a set of tags for a client traffic class.
+func TrafficClassTags(class string) map[string]string {
+	return map[string]string{
+		TrafficClassTagName: class,
+	}
+}
+
 // RequestFailureCodeTags returns a set of tags for a request failure.
 func RequestFailureCodeTags(statusCode codes.Code) map[string]string {
 	asStr := canonicalString(statusCode)
@@ -0,0 +1,80 @@
+package retry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	"google.golang.org/grpc"
+)
+
+// Budget is a token bucket shared by all calls on a connection that caps how
+// many retries are made relative to successful calls. Every attempt that fails
+// with a retryable error takes one token and every successful attempt returns
+// tokenRatio tokens. Retries are only allowed while more than half of the
+// tokens remain, so once the server starts failing, the ratio of retries to
+// successful calls converges on tokenRatio instead of every caller retrying in
+// lockstep. This is the same scheme as gRPC's retry throttling.
+type Budget struct {
+	maxTokens  float64
+	tokenRatio float64
+
+	lock   sync.Mutex
+	tokens float64
+}
+
+// NewBudget creates a full retry budget.
+func NewBudget(maxTokens, tokenRatio float64) *Budget {
+	return &Budget{maxTokens: maxTokens, tokenRatio: tokenRatio, tokens: maxTokens}
+}
+
+// AllowRetry returns whether the budget currently allows a retry.
+func (b *Budget) AllowRetry() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.tokens > b.maxTokens/2
+}
+
+func (b *Budget) recordSuccess() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.tokens = min(b.tokens+b.tokenRatio, b.maxTokens)
+}
+
+func (b *Budget) recordFailure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.tokens = max(b.tokens-1, 0)
+}
+
+// NewBudgetInterceptor creates a gRPC interceptor that records the outcome of
+// every attempt in the budget. It must be placed after the retry interceptor so
+// it sees each attempt rather than the overall call.
+func NewBudgetInterceptor(budget *Budget, excludeInternal *atomic.Bool) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			budget.recordSuccess()
+		} else if IsRetryable(err, excludeInternal) {
+			budget.recordFailure()
+		}
+		return err
+	}
+}
+
+// allowRetry checks the budget, if any, for a retry of the given call and
+// records a denied retry in the metrics handler.
+func allowRetry(
+	ctx context.Context,
+	budget *Budget,
+	metricsHandler metrics.Handler,
+	method string,
+	req interface{},
+) bool {
+	if budget == nil || budget.AllowRetry() {
+		return true
+	}
+	metrics.RequestHandler(ctx, metricsHandler, method, req).Counter(metrics.TemporalRequestRetryDenied).Inc(1)
+	return false
+}
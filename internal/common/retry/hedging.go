@@ -0,0 +1,81 @@
+package retry
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewHedgingInterceptor creates a gRPC interceptor that hedges the calls for
+// which shouldHedge returns true. If an attempt hasn't completed after delay,
+// another one is sent in parallel, up to maxAttempts in total, and the first
+// successful response is used. Hedged attempts are only sent while the budget,
+// if any, allows retries, and are counted in the metrics handler. Long polls
+// are never hedged. It must be placed after the retry interceptor so that each
+// retry is hedged rather than each hedged attempt being retried.
+//
+// Only idempotent calls should be hedged, and call options are shared by all
+// attempts so options that capture results, like grpc.Peer, are not supported.
+func NewHedgingInterceptor(
+	shouldHedge func(method string, req interface{}) bool,
+	delay time.Duration,
+	maxAttempts int,
+	budget *Budget,
+	excludeInternal *atomic.Bool,
+	metricsHandler metrics.Handler,
+) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		longPoll, _ := ctx.Value(metrics.LongPollContextKey{}).(bool)
+		replyMessage, ok := reply.(proto.Message)
+		if longPoll || !ok || maxAttempts < 2 || !shouldHedge(method, req) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		// Outstanding attempts are canceled once a result is returned
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		type result struct {
+			reply proto.Message
+			err   error
+		}
+		results := make(chan result, maxAttempts)
+		sendAttempt := func() {
+			attemptReply := replyMessage.ProtoReflect().New().Interface()
+			go func() {
+				err := invoker(ctx, method, req, attemptReply, cc, opts...)
+				results <- result{reply: attemptReply, err: err}
+			}()
+		}
+
+		sendAttempt()
+		sent, pending := 1, 1
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+				if sent < maxAttempts && (budget == nil || budget.AllowRetry()) {
+					metrics.RequestHandler(ctx, metricsHandler, method, req).Counter(metrics.TemporalRequestHedge).Inc(1)
+					sendAttempt()
+					sent++
+					pending++
+					timer.Reset(delay)
+				}
+			case r := <-results:
+				pending--
+				if r.err == nil {
+					proto.Reset(replyMessage)
+					proto.Merge(replyMessage, r.reply)
+					return nil
+				} else if pending == 0 || !IsRetryable(r.err, excludeInternal) {
+					return r.err
+				}
+				// Otherwise a retryable failure, so wait for the other attempts
+			}
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/util/backoffutils"
+	"go.temporal.io/sdk/internal/common/metrics"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -110,8 +111,9 @@ var (
 
 // NewRetryOptionsInterceptor creates a new gRPC interceptor that populates retry options for each call based on values
 // provided in the context. The atomic bool is checked each call to determine whether internals are included in retry.
-// If not present or false, internals are assumed to be included.
-func NewRetryOptionsInterceptor(excludeInternal *atomic.Bool) grpc.UnaryClientInterceptor {
+// If not present or false, internals are assumed to be included. If a budget is given, retries it doesn't allow are
+// not made and are counted in the metrics handler instead.
+func NewRetryOptionsInterceptor(excludeInternal *atomic.Bool, budget *Budget, metricsHandler metrics.Handler) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		if rc, ok := ctx.Value(ConfigKey).(*GrpcRetryConfig); ok {
 			if _, ok := ctx.Deadline(); !ok {
@@ -135,7 +137,7 @@ func NewRetryOptionsInterceptor(excludeInternal *atomic.Bool) grpc.UnaryClientIn
 				opts = append(opts, grpc_retry.WithMax(math.MaxUint32))
 			}
 			opts = append(opts, grpc_retry.WithRetriable(func(err error) bool {
-				return IsRetryable(err, excludeInternal)
+				return IsRetryable(err, excludeInternal) && allowRetry(ctx, budget, metricsHandler, method, req)
 			}))
 		} else {
 			// Do not retry if retry config is not set.
@@ -160,7 +162,7 @@ func IsRetryable(err error, excludeInternalFromRetry *atomic.Bool) bool {
 		}
 	}
 	if errCode == codes.Internal {
-		return !excludeInternalFromRetry.Load()
+		return excludeInternalFromRetry == nil || !excludeInternalFromRetry.Load()
 	}
 	return false
 }
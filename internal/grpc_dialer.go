@@ -3,13 +3,17 @@ package internal
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync/atomic"
 	"time"
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
 	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/internal/common/metrics"
 	"go.temporal.io/sdk/internal/common/retry"
+	ilog "go.temporal.io/sdk/internal/log"
+	"go.temporal.io/sdk/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
@@ -25,7 +29,11 @@ type (
 		HostPort              string
 		UserConnectionOptions ConnectionOptions
 		RequiredInterceptors  []grpc.UnaryClientInterceptor
-		DefaultServiceConfig  string
+		// Interceptors of the endpoint health checks, see healthCheckInterceptors.
+		HealthCheckInterceptors []grpc.UnaryClientInterceptor
+		DefaultServiceConfig    string
+		Logger                  log.Logger
+		MetricsHandler          metrics.Handler
 	}
 )
 
@@ -58,6 +66,15 @@ const (
 
 	// temporalNamespaceHeaderKey is the header key that should contain the target namespace of the request.
 	temporalNamespaceHeaderKey = "temporal-namespace"
+
+	// defaultRetryBudgetMaxTokens is the retry budget size if one is not specified.
+	defaultRetryBudgetMaxTokens = 100
+
+	// defaultRetryBudgetTokenRatio is the retry budget token ratio if one is not specified.
+	defaultRetryBudgetTokenRatio = 0.1
+
+	// defaultHedgingMaxAttempts is the maximum number of hedged attempts if one is not specified.
+	defaultHedgingMaxAttempts = 2
 )
 
 func dial(params dialParameters) (*grpc.ClientConn, error) {
@@ -90,9 +107,34 @@ func dial(params dialParameters) (*grpc.ClientConn, error) {
 	}
 	cp.Backoff.BaseDelay = retryPollOperationInitialInterval
 	cp.Backoff.MaxDelay = retryPollOperationMaxInterval
+
+	// With multiple endpoints, the connection resolves addresses through a
+	// balancer that only hands out healthy endpoints. Health check connections
+	// use the same security and user-supplied options, and only the interceptors
+	// that authenticate calls, so checks aren't reported as client calls.
+	target, serviceConfig := params.HostPort, params.DefaultServiceConfig
+	var balancer *endpointBalancer
+	if len(params.UserConnectionOptions.Endpoints) > 0 || params.UserConnectionOptions.SRVName != "" {
+		logger, metricsHandler := params.Logger, params.MetricsHandler
+		if logger == nil {
+			logger = ilog.NewDefaultLogger()
+		}
+		if metricsHandler == nil {
+			metricsHandler = metrics.NopHandler
+		}
+		healthCheckOpts := append(slices.Clone(securityOptions), grpc.WithChainUnaryInterceptor(params.HealthCheckInterceptors...))
+		healthCheckOpts = append(healthCheckOpts, params.UserConnectionOptions.DialOptions...)
+		var err error
+		balancer, err = newEndpointBalancer(params.UserConnectionOptions, healthCheckOpts, logger, metricsHandler)
+		if err != nil {
+			return nil, err
+		}
+		target, serviceConfig = balancer.target(), balancer.serviceConfig()
+	}
+
 	opts := []grpc.DialOption{
 		grpc.WithChainUnaryInterceptor(params.RequiredInterceptors...),
-		grpc.WithDefaultServiceConfig(params.DefaultServiceConfig),
+		grpc.WithDefaultServiceConfig(serviceConfig),
 		grpc.WithConnectParams(cp),
 	}
 
@@ -123,27 +165,70 @@ func dial(params dialParameters) (*grpc.ClientConn, error) {
 	// Append any user-supplied options
 	opts = append(opts, params.UserConnectionOptions.DialOptions...)
 
-	return grpc.NewClient(params.HostPort, opts...)
+	if balancer != nil {
+		opts = append(opts, grpc.WithResolvers(balancer))
+		conn, err := grpc.NewClient(target, opts...)
+		if err != nil {
+			balancer.stop()
+			return nil, err
+		}
+		balancer.start(conn)
+		return conn, nil
+	}
+	return grpc.NewClient(target, opts...)
 }
 
 func requiredInterceptors(
 	clientOptions *ClientOptions,
 	excludeInternalFromRetry *atomic.Bool,
 ) []grpc.UnaryClientInterceptor {
+	var budget *retry.Budget
+	if budgetOptions := clientOptions.ConnectionOptions.RetryBudget; budgetOptions != nil {
+		maxTokens, tokenRatio := budgetOptions.MaxTokens, budgetOptions.TokenRatio
+		if maxTokens == 0 {
+			maxTokens = defaultRetryBudgetMaxTokens
+		}
+		if tokenRatio == 0 {
+			tokenRatio = defaultRetryBudgetTokenRatio
+		}
+		budget = retry.NewBudget(maxTokens, tokenRatio)
+	}
 	interceptors := []grpc.UnaryClientInterceptor{
 		errorInterceptor,
+	}
+	if len(clientOptions.TrafficShaping.Classes) > 0 {
+		// Limit calls before they are counted as requests or retried.
+		interceptors = append(interceptors, newTrafficShapingInterceptor(clientOptions.TrafficShaping, clientOptions.MetricsHandler))
+	}
+	interceptors = append(interceptors,
 		// Report aggregated metrics for the call, this is done outside of the retry loop.
 		metrics.NewGRPCInterceptor(clientOptions.MetricsHandler, "", clientOptions.DisableErrorCodeMetricTags),
 		// By default the grpc retry interceptor *is disabled*, preventing accidental use of retries.
 		// We add call options for retry configuration based on the values present in the context.
-		retry.NewRetryOptionsInterceptor(excludeInternalFromRetry),
+		retry.NewRetryOptionsInterceptor(excludeInternalFromRetry, budget, clientOptions.MetricsHandler),
 		// Performs retries *IF* retry options are set for the call.
 		grpc_retry.UnaryClientInterceptor(),
+	)
+	if hedging := clientOptions.ConnectionOptions.Hedging; hedging != nil {
+		maxAttempts := hedging.MaxAttempts
+		if maxAttempts == 0 {
+			maxAttempts = defaultHedgingMaxAttempts
+		}
+		interceptors = append(interceptors, retry.NewHedgingInterceptor(
+			isHedgeableCall, hedging.Delay, maxAttempts, budget, excludeInternalFromRetry, clientOptions.MetricsHandler))
+	}
+	interceptors = append(interceptors,
 		// Prevents retrying grpc message too large errors, while allowing retries of other resource exhausted errors.
 		retry.GrpcMessageTooLargeErrorInterceptor,
+	)
+	if budget != nil {
+		// Record the outcome of every attempt in the retry budget.
+		interceptors = append(interceptors, retry.NewBudgetInterceptor(budget, excludeInternalFromRetry))
+	}
+	interceptors = append(interceptors,
 		// Report metrics for every call made to the server.
 		metrics.NewGRPCInterceptor(clientOptions.MetricsHandler, attemptSuffix, clientOptions.DisableErrorCodeMetricTags),
-	}
+	)
 	if clientOptions.HeadersProvider != nil {
 		interceptors = append(interceptors, headersProviderInterceptor(clientOptions.HeadersProvider))
 	}
@@ -162,6 +247,35 @@ func requiredInterceptors(
 	return interceptors
 }
 
+// healthCheckInterceptors returns the interceptors that authenticate calls, in
+// the same order as requiredInterceptors, for the endpoint health checks.
+func healthCheckInterceptors(clientOptions *ClientOptions) []grpc.UnaryClientInterceptor {
+	var interceptors []grpc.UnaryClientInterceptor
+	if clientOptions.HeadersProvider != nil {
+		interceptors = append(interceptors, headersProviderInterceptor(clientOptions.HeadersProvider))
+	}
+	if clientOptions.Credentials != nil {
+		if interceptor := clientOptions.Credentials.gRPCInterceptor(); interceptor != nil {
+			interceptors = append(interceptors, interceptor)
+		}
+	}
+	return interceptors
+}
+
+// isHedgeableCall returns whether the call is an idempotent read that can be
+// hedged. History long polls are not hedged.
+func isHedgeableCall(method string, req interface{}) bool {
+	switch method {
+	case workflowservice.WorkflowService_DescribeWorkflowExecution_FullMethodName,
+		workflowservice.WorkflowService_QueryWorkflow_FullMethodName:
+		return true
+	case workflowservice.WorkflowService_GetWorkflowExecutionHistory_FullMethodName:
+		historyReq, _ := req.(*workflowservice.GetWorkflowExecutionHistoryRequest)
+		return historyReq != nil && !historyReq.GetWaitNewEvent()
+	}
+	return false
+}
+
 func namespaceProviderInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		if nsReq, ok := req.(interface{ GetNamespace() string }); ok {
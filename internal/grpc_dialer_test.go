@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -19,9 +20,11 @@ import (
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/errordetails/v1"
 	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/internal/common/metrics"
 	"go.temporal.io/sdk/internal/common/retry"
+	ilog "go.temporal.io/sdk/internal/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
@@ -403,6 +406,306 @@ func TestCustomResolver(t *testing.T) {
 	require.Equal(t, 4, s2.signalWorkflowInvokeCount())
 }
 
+func TestEndpointFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s1, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer s1.Stop()
+	s2, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer s2.Stop()
+	for _, s := range []*testGRPCServer{s1, s2} {
+		s.healthServer.SetServingStatus(workflowServiceHealthCheckName, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+
+	logger := ilog.NewMemoryLogger()
+	metricsHandler := metrics.NewCapturingHandler()
+	client, err := DialClient(ctx, ClientOptions{
+		Logger:         logger,
+		MetricsHandler: metricsHandler,
+		ConnectionOptions: ConnectionOptions{
+			Endpoints:                   []string{s1.addr, s2.addr},
+			LoadBalancingPolicy:         LoadBalancingPolicyPickFirst,
+			EndpointHealthCheckInterval: 50 * time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// All calls go to the first endpoint
+	require.NoError(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+	require.NoError(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+	require.Equal(t, 2, s1.signalWorkflowInvokeCount())
+	require.Equal(t, 0, s2.signalWorkflowInvokeCount())
+
+	// Mark the first as not serving and confirm calls fail over to the second
+	s1.healthServer.SetServingStatus(workflowServiceHealthCheckName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	require.Eventually(t, func() bool {
+		s2.resetSignalWorkflowInvokeCount()
+		return client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil) == nil &&
+			s2.signalWorkflowInvokeCount() == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	s1.resetSignalWorkflowInvokeCount()
+	require.NoError(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+	require.Equal(t, 0, s1.signalWorkflowInvokeCount())
+
+	// Confirm failover was logged and reported
+	var loggedFailover bool
+	for _, line := range logger.Lines() {
+		loggedFailover = loggedFailover || strings.Contains(line, "Failing over to endpoint From "+s1.addr+" To "+s2.addr)
+	}
+	require.True(t, loggedFailover)
+	healthy := map[string]float64{}
+	channelState := map[string]float64{}
+	for _, gauge := range metricsHandler.Gauges() {
+		switch gauge.Name {
+		case metrics.ClientEndpointHealthy:
+			healthy[gauge.Tags[metrics.EndpointTagName]] = gauge.Value()
+		case metrics.ClientChannelState:
+			channelState[gauge.Tags[metrics.ChannelStateTagName]] = gauge.Value()
+		}
+	}
+	require.Equal(t, map[string]float64{s1.addr: 0, s2.addr: 1}, healthy)
+	require.Equal(t, float64(1), channelState["ready"])
+	require.Equal(t, float64(0), channelState["idle"])
+	var failovers int64
+	for _, counter := range metricsHandler.Counters() {
+		if counter.Name == metrics.ClientEndpointFailover && counter.Tags[metrics.EndpointTagName] == s1.addr {
+			failovers += counter.Value()
+		}
+	}
+	require.Equal(t, int64(1), failovers)
+
+	// Bring the first back and confirm it is used again in rotation
+	s1.healthServer.SetServingStatus(workflowServiceHealthCheckName, grpc_health_v1.HealthCheckResponse_SERVING)
+	require.Eventually(t, func() bool {
+		for _, gauge := range metricsHandler.Gauges() {
+			if gauge.Name == metrics.ClientEndpointHealthy && gauge.Tags[metrics.EndpointTagName] == s1.addr {
+				return gauge.Value() == 1
+			}
+		}
+		return false
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestEndpointSRVRoundRobin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var servers []*testGRPCServer
+	var records []*net.SRV
+	for i := 0; i < 3; i++ {
+		s, err := startTestGRPCServer()
+		require.NoError(t, err)
+		defer s.Stop()
+		s.healthServer.SetServingStatus(workflowServiceHealthCheckName, grpc_health_v1.HealthCheckResponse_SERVING)
+		servers = append(servers, s)
+		host, port, err := net.SplitHostPort(s.addr)
+		require.NoError(t, err)
+		portNum, err := strconv.Atoi(port)
+		require.NoError(t, err)
+		records = append(records, &net.SRV{Target: host + ".", Port: uint16(portNum)})
+	}
+	// Third is unhealthy from the start
+	servers[2].healthServer.SetServingStatus(workflowServiceHealthCheckName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	client, err := DialClient(ctx, ClientOptions{
+		ConnectionOptions: ConnectionOptions{
+			SRVName:                     "_temporal._tcp.example.com",
+			EndpointHealthCheckInterval: 50 * time.Millisecond,
+			lookupSRV: func(ctx context.Context, name string) ([]*net.SRV, error) {
+				require.Equal(t, "_temporal._tcp.example.com", name)
+				return records, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Wait until calls are spread across only the two healthy servers
+	require.Eventually(t, func() bool {
+		for _, s := range servers {
+			s.resetSignalWorkflowInvokeCount()
+		}
+		for i := 0; i < 6; i++ {
+			if client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil) != nil {
+				return false
+			}
+		}
+		return servers[0].signalWorkflowInvokeCount() == 3 &&
+			servers[1].signalWorkflowInvokeCount() == 3 &&
+			servers[2].signalWorkflowInvokeCount() == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// Both options cannot be set, and lookup failures are reported on dial
+	_, err = DialClient(ctx, ClientOptions{
+		ConnectionOptions: ConnectionOptions{Endpoints: []string{servers[0].addr}, SRVName: "foo"},
+	})
+	require.EqualError(t, err, "cannot set both Endpoints and SRVName in ConnectionOptions")
+	_, err = DialClient(ctx, ClientOptions{
+		ConnectionOptions: ConnectionOptions{
+			SRVName: "foo",
+			lookupSRV: func(context.Context, string) ([]*net.SRV, error) {
+				return nil, fmt.Errorf("no such host")
+			},
+		},
+	})
+	require.EqualError(t, err, `failed resolving SRV record "foo": no such host`)
+}
+
+func TestEndpointHealthCheckAuthentication(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// Health checks without the API key are rejected, as a server requiring
+	// authentication would
+	var checkedAuthorization atomic.Value
+	requireAPIKey := grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == grpc_health_v1.Health_Check_FullMethodName {
+			authorization := metadata.ValueFromIncomingContext(ctx, "authorization")
+			checkedAuthorization.Store(authorization)
+			if len(authorization) != 1 || authorization[0] != "Bearer my-api-key" {
+				return nil, status.Error(codes.Unauthenticated, "missing API key")
+			}
+		}
+		return handler(ctx, req)
+	})
+	s1, err := startTestGRPCServer(requireAPIKey)
+	require.NoError(t, err)
+	defer s1.Stop()
+	s2, err := startTestGRPCServer(requireAPIKey)
+	require.NoError(t, err)
+	defer s2.Stop()
+	for _, s := range []*testGRPCServer{s1, s2} {
+		s.healthServer.SetServingStatus(workflowServiceHealthCheckName, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+
+	for name, options := range map[string]ClientOptions{
+		"credentials":      {Credentials: NewAPIKeyStaticCredentials("my-api-key")},
+		"headers provider": {HeadersProvider: authHeadersProvider{token: "Bearer my-api-key"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			metricsHandler := metrics.NewCapturingHandler()
+			options.MetricsHandler = metricsHandler
+			options.ConnectionOptions = ConnectionOptions{
+				Endpoints:                   []string{s1.addr, s2.addr},
+				EndpointHealthCheckInterval: 50 * time.Millisecond,
+				TLSDisabled:                 true, // Test server doesn't use TLS
+			}
+			checkedAuthorization.Store([]string(nil))
+			client, err := DialClient(ctx, options)
+			require.NoError(t, err)
+			defer client.Close()
+
+			// Both endpoints pass their authenticated health checks
+			require.Eventually(t, func() bool {
+				healthy := map[string]float64{}
+				for _, gauge := range metricsHandler.Gauges() {
+					if gauge.Name == metrics.ClientEndpointHealthy {
+						healthy[gauge.Tags[metrics.EndpointTagName]] = gauge.Value()
+					}
+				}
+				return checkedAuthorization.Load().([]string) != nil && healthy[s1.addr] == 1 && healthy[s2.addr] == 1
+			}, 5*time.Second, 50*time.Millisecond)
+			require.Equal(t, []string{"Bearer my-api-key"}, checkedAuthorization.Load())
+			require.NoError(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+	metricsHandler := metrics.NewCapturingHandler()
+	client, err := DialClient(context.Background(), ClientOptions{
+		HostPort:       srv.addr,
+		MetricsHandler: metricsHandler,
+		ConnectionOptions: ConnectionOptions{
+			RetryBudget: &RetryBudgetOptions{MaxTokens: 4, TokenRatio: 1},
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Each failure takes a token and retries stop once only half remain, so
+	// the first call is attempted twice and the next ones only once
+	srv.signalWorkflowExecutionResponseError = status.Error(codes.Unavailable, "unavailable")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.Error(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+	require.Equal(t, 2, srv.signalWorkflowInvokeCount())
+	require.Error(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+	require.Equal(t, 3, srv.signalWorkflowInvokeCount())
+	var denied int64
+	for _, counter := range metricsHandler.Counters() {
+		if counter.Name == metrics.TemporalRequestRetryDenied {
+			require.Equal(t, "SignalWorkflowExecution", counter.Tags[metrics.OperationTagName])
+			denied += counter.Value()
+		}
+	}
+	require.Equal(t, int64(2), denied)
+
+	// Successful calls refill the budget so retries are allowed again
+	srv.signalWorkflowExecutionResponseError = nil
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+	}
+	srv.resetSignalWorkflowInvokeCount()
+	srv.signalWorkflowExecutionResponseError = status.Error(codes.Unavailable, "unavailable")
+	require.Error(t, client.SignalWorkflow(ctx, "workflowid", "runid", "signalname", nil))
+	require.Equal(t, 2, srv.signalWorkflowInvokeCount())
+}
+
+func TestHedging(t *testing.T) {
+	srv, err := startTestGRPCServer()
+	require.NoError(t, err)
+	defer srv.Stop()
+	metricsHandler := metrics.NewCapturingHandler()
+	client, err := DialClient(context.Background(), ClientOptions{
+		HostPort:       srv.addr,
+		MetricsHandler: metricsHandler,
+		ConnectionOptions: ConnectionOptions{
+			Hedging: &HedgingOptions{Delay: 50 * time.Millisecond},
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// The first attempt hangs, so the hedged attempt's response is used and the
+	// first attempt is canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv.describeWorkflowExecutionHangFirst.Store(true)
+	resp, err := client.DescribeWorkflowExecution(ctx, "workflowid", "runid")
+	require.NoError(t, err)
+	require.Equal(t, "workflowid", resp.GetWorkflowExecutionInfo().GetExecution().GetWorkflowId())
+	require.Equal(t, 2, int(srv.describeWorkflowExecutionCount.Load()))
+	var hedges int64
+	for _, counter := range metricsHandler.Counters() {
+		if counter.Name == metrics.TemporalRequestHedge {
+			hedges += counter.Value()
+		}
+	}
+	require.Equal(t, int64(1), hedges)
+
+	// Calls completing within the delay aren't hedged, and other calls never are
+	srv.describeWorkflowExecutionHangFirst.Store(false)
+	srv.describeWorkflowExecutionCount.Store(0)
+	_, err = client.DescribeWorkflowExecution(ctx, "workflowid", "runid")
+	require.NoError(t, err)
+	require.Equal(t, 1, int(srv.describeWorkflowExecutionCount.Load()))
+	require.True(t, isHedgeableCall(workflowservice.WorkflowService_QueryWorkflow_FullMethodName, nil))
+	require.True(t, isHedgeableCall(workflowservice.WorkflowService_GetWorkflowExecutionHistory_FullMethodName,
+		&workflowservice.GetWorkflowExecutionHistoryRequest{}))
+	require.False(t, isHedgeableCall(workflowservice.WorkflowService_GetWorkflowExecutionHistory_FullMethodName,
+		&workflowservice.GetWorkflowExecutionHistoryRequest{WaitNewEvent: true}))
+	require.False(t, isHedgeableCall(workflowservice.WorkflowService_SignalWorkflowExecution_FullMethodName, nil))
+
+	// Delay is required
+	_, err = DialClient(ctx, ClientOptions{HostPort: srv.addr, ConnectionOptions: ConnectionOptions{Hedging: &HedgingOptions{}}})
+	require.EqualError(t, err, "hedging delay must be positive")
+}
+
 func TestResourceExhaustedCause(t *testing.T) {
 	// Start gRPC server
 	srv, err := startTestGRPCServer()
@@ -612,15 +915,17 @@ type testGRPCServer struct {
 	lastSignalWorkflowExecutionContext   context.Context
 	signalWorkflowExecutionResponse      workflowservice.SignalWorkflowExecutionResponse
 	signalWorkflowExecutionResponseError error
+	describeWorkflowExecutionCount       atomic.Int32
+	describeWorkflowExecutionHangFirst   atomic.Bool
 }
 
-func startTestGRPCServer() (*testGRPCServer, error) {
+func startTestGRPCServer(opts ...grpc.ServerOption) (*testGRPCServer, error) {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
 		return nil, err
 	}
 	t := &testGRPCServer{
-		Server:       grpc.NewServer(),
+		Server:       grpc.NewServer(opts...),
 		addr:         l.Addr().String(),
 		healthServer: health.NewServer(),
 	}
@@ -677,6 +982,19 @@ func (t *testGRPCServer) SignalWorkflowExecution(
 	return &t.signalWorkflowExecutionResponse, t.signalWorkflowExecutionResponseError
 }
 
+func (t *testGRPCServer) DescribeWorkflowExecution(
+	ctx context.Context,
+	req *workflowservice.DescribeWorkflowExecutionRequest,
+) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	if t.describeWorkflowExecutionCount.Add(1) == 1 && t.describeWorkflowExecutionHangFirst.Load() {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{Execution: req.GetExecution()},
+	}, nil
+}
+
 func (t *testGRPCServer) signalWorkflowInvokeCount() int {
 	return int(atomic.LoadInt32(&t.sigWfCount))
 }
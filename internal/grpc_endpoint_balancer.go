@@ -0,0 +1,436 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/internal/common/metrics"
+	"go.temporal.io/sdk/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/resolver"
+)
+
+// LoadBalancingPolicy controls how a client spreads calls across the
+// endpoints in [ConnectionOptions.Endpoints] or [ConnectionOptions.SRVName].
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.LoadBalancingPolicy]
+type LoadBalancingPolicy int
+
+const (
+	// LoadBalancingPolicyRoundRobin spreads calls across all healthy endpoints.
+	// This is the default.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.LoadBalancingPolicyRoundRobin]
+	LoadBalancingPolicyRoundRobin LoadBalancingPolicy = iota
+
+	// LoadBalancingPolicyPickFirst sends all calls to the first healthy endpoint
+	// in the configured order, falling back to the next one when it becomes
+	// unhealthy. For SRV records the order is the one returned by the lookup,
+	// i.e. by priority and weight.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.LoadBalancingPolicyPickFirst]
+	LoadBalancingPolicyPickFirst
+)
+
+const (
+	// endpointsResolverScheme is the prefix of the per-connection resolver
+	// scheme used when multiple endpoints are configured.
+	endpointsResolverScheme = "temporal-endpoints"
+
+	// pickFirstServiceConfig is the gRPC service config used with
+	// LoadBalancingPolicyPickFirst.
+	pickFirstServiceConfig = `{"loadBalancingConfig": [{"pick_first":{}}]}`
+
+	// defaultEndpointHealthCheckInterval is the health check interval if one is
+	// not specified.
+	defaultEndpointHealthCheckInterval = 10 * time.Second
+
+	// workflowServiceHealthCheckName is the service name used in gRPC health
+	// checks.
+	workflowServiceHealthCheckName = "temporal.api.workflowservice.v1.WorkflowService"
+)
+
+// endpointBalancer feeds the healthy subset of a set of endpoints to a gRPC
+// connection. It acts as the connection's resolver, periodically checks each
+// endpoint with the gRPC health check API over a dedicated connection, and
+// republishes the address list whenever the health of an endpoint changes.
+// gRPC's round_robin or pick_first balancer then picks among the published
+// addresses.
+type endpointBalancer struct {
+	scheme         string
+	endpoints      []string
+	srvName        string
+	lookupSRV      func(ctx context.Context, name string) ([]*net.SRV, error)
+	policy         LoadBalancingPolicy
+	interval       time.Duration
+	dialOptions    []grpc.DialOption
+	logger         log.Logger
+	metricsHandler metrics.Handler
+	closeCh        chan struct{}
+	closeOnce      sync.Once
+
+	lock sync.Mutex
+	// Resolver connection, nil until gRPC builds the resolver
+	cc resolver.ClientConn
+	// Current endpoints in priority order
+	current []string
+	// Endpoint health, keyed by endpoint. Endpoints are assumed healthy until
+	// the first failed check.
+	healthy map[string]bool
+	// Dedicated health check connections, keyed by endpoint
+	healthConns map[string]*grpc.ClientConn
+	// Preferred endpoint when using LoadBalancingPolicyPickFirst
+	preferred string
+}
+
+type endpointResolver struct {
+	balancer *endpointBalancer
+	cc       resolver.ClientConn
+}
+
+func lookupSRV(ctx context.Context, name string) ([]*net.SRV, error) {
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	return addrs, err
+}
+
+// newEndpointBalancer creates a balancer for the endpoints or SRV name in the
+// given options. The SRV name, if any, is resolved before returning so that
+// lookup failures are reported when dialing. Health checking does not start
+// until start is called.
+func newEndpointBalancer(
+	options ConnectionOptions,
+	dialOptions []grpc.DialOption,
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+) (*endpointBalancer, error) {
+	if len(options.Endpoints) > 0 && options.SRVName != "" {
+		return nil, fmt.Errorf("cannot set both Endpoints and SRVName in ConnectionOptions")
+	}
+	interval := options.EndpointHealthCheckInterval
+	if interval == 0 {
+		interval = defaultEndpointHealthCheckInterval
+	}
+	b := &endpointBalancer{
+		scheme:         endpointsResolverScheme + "-" + uuid.NewString(),
+		endpoints:      options.Endpoints,
+		srvName:        options.SRVName,
+		lookupSRV:      options.lookupSRV,
+		policy:         options.LoadBalancingPolicy,
+		interval:       interval,
+		dialOptions:    dialOptions,
+		logger:         logger,
+		metricsHandler: metricsHandler,
+		closeCh:        make(chan struct{}),
+		healthy:        map[string]bool{},
+		healthConns:    map[string]*grpc.ClientConn{},
+	}
+	if b.lookupSRV == nil {
+		b.lookupSRV = lookupSRV
+	}
+	endpoints, err := b.resolveEndpoints(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	b.setEndpoints(endpoints)
+	return b, nil
+}
+
+// target returns the dial target that routes the connection to this balancer.
+// The first endpoint is used as the target's endpoint so that it becomes the
+// default authority of the connection.
+func (b *endpointBalancer) target() string {
+	return b.scheme + ":///" + b.current[0]
+}
+
+func (b *endpointBalancer) serviceConfig() string {
+	if b.policy == LoadBalancingPolicyPickFirst {
+		return pickFirstServiceConfig
+	}
+	return defaultServiceConfig
+}
+
+// start begins health checking the endpoints and reporting the state of the
+// given connection. Both stop once the connection is closed.
+func (b *endpointBalancer) start(conn *grpc.ClientConn) {
+	go b.watchConnState(conn)
+	go b.runHealthChecks()
+}
+
+func (b *endpointBalancer) stop() {
+	b.closeOnce.Do(func() {
+		close(b.closeCh)
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		for endpoint, conn := range b.healthConns {
+			_ = conn.Close()
+			delete(b.healthConns, endpoint)
+		}
+	})
+}
+
+func (b *endpointBalancer) watchConnState(conn *grpc.ClientConn) {
+	for state := conn.GetState(); ; state = conn.GetState() {
+		b.recordChannelState(state)
+		if state == connectivity.Shutdown {
+			b.stop()
+			return
+		}
+		conn.WaitForStateChange(context.Background(), state)
+	}
+}
+
+func (b *endpointBalancer) recordChannelState(state connectivity.State) {
+	for _, s := range []connectivity.State{
+		connectivity.Idle,
+		connectivity.Connecting,
+		connectivity.Ready,
+		connectivity.TransientFailure,
+		connectivity.Shutdown,
+	} {
+		var value float64
+		if s == state {
+			value = 1
+		}
+		b.metricsHandler.WithTags(metrics.ChannelStateTags(channelStateTagValue(s))).
+			Gauge(metrics.ClientChannelState).Update(value)
+	}
+}
+
+func channelStateTagValue(state connectivity.State) string {
+	return strings.ToLower(state.String())
+}
+
+func (b *endpointBalancer) runHealthChecks() {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		b.checkEndpoints()
+		select {
+		case <-b.closeCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkEndpoints re-resolves the SRV name if any, checks the health of every
+// endpoint, and republishes the addresses if anything changed.
+func (b *endpointBalancer) checkEndpoints() {
+	ctx, cancel := context.WithTimeout(context.Background(), b.interval)
+	defer cancel()
+	go func() {
+		select {
+		case <-b.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if b.srvName != "" {
+		if endpoints, err := b.resolveEndpoints(ctx); err != nil {
+			b.logger.Warn("Failed resolving SRV record, keeping previous endpoints",
+				"SRVName", b.srvName, tagError, err)
+		} else {
+			b.setEndpoints(endpoints)
+		}
+	}
+
+	b.lock.Lock()
+	endpoints := slices.Clone(b.current)
+	b.lock.Unlock()
+
+	results := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		conn, err := b.healthConn(endpoint)
+		if err != nil {
+			results[i] = err
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = checkHealth(ctx, conn)
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-b.closeCh:
+		return
+	default:
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for i, endpoint := range endpoints {
+		healthy := results[i] == nil
+		wasHealthy, known := b.healthy[endpoint]
+		if !known {
+			continue
+		}
+		if wasHealthy && !healthy {
+			b.logger.Warn("Endpoint became unhealthy, removing from rotation",
+				tagEndpoint, endpoint, tagError, results[i])
+			b.metricsHandler.WithTags(metrics.EndpointTags(endpoint)).Counter(metrics.ClientEndpointFailover).Inc(1)
+		} else if !wasHealthy && healthy {
+			b.logger.Info("Endpoint became healthy, adding back to rotation", tagEndpoint, endpoint)
+		}
+		b.healthy[endpoint] = healthy
+	}
+	b.updateStateLocked()
+}
+
+func (b *endpointBalancer) resolveEndpoints(ctx context.Context) ([]string, error) {
+	if b.srvName == "" {
+		if len(b.endpoints) == 0 {
+			return nil, fmt.Errorf("no endpoints configured")
+		}
+		return b.endpoints, nil
+	}
+	records, err := b.lookupSRV(ctx, b.srvName)
+	if err != nil {
+		return nil, fmt.Errorf("failed resolving SRV record %q: %w", b.srvName, err)
+	} else if len(records) == 0 {
+		return nil, fmt.Errorf("SRV record %q has no targets", b.srvName)
+	}
+	endpoints := make([]string, len(records))
+	for i, record := range records {
+		endpoints[i] = net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port)))
+	}
+	return endpoints, nil
+}
+
+// setEndpoints replaces the set of endpoints, keeping the known health of
+// endpoints that remain and closing health connections of those that don't.
+func (b *endpointBalancer) setEndpoints(endpoints []string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for endpoint := range b.healthy {
+		if !slices.Contains(endpoints, endpoint) {
+			delete(b.healthy, endpoint)
+			b.metricsHandler.WithTags(metrics.EndpointTags(endpoint)).Gauge(metrics.ClientEndpointHealthy).Update(0)
+			if conn := b.healthConns[endpoint]; conn != nil {
+				_ = conn.Close()
+				delete(b.healthConns, endpoint)
+			}
+		}
+	}
+	for _, endpoint := range endpoints {
+		if _, ok := b.healthy[endpoint]; !ok {
+			b.healthy[endpoint] = true
+		}
+	}
+	if !slices.Equal(b.current, endpoints) {
+		b.current = slices.Clone(endpoints)
+		b.updateStateLocked()
+	}
+}
+
+func (b *endpointBalancer) healthConn(endpoint string) (*grpc.ClientConn, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	select {
+	case <-b.closeCh:
+		return nil, fmt.Errorf("balancer closed")
+	default:
+	}
+	if conn := b.healthConns[endpoint]; conn != nil {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(endpoint, b.dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	b.healthConns[endpoint] = conn
+	return conn, nil
+}
+
+// addressesLocked returns the healthy endpoints in priority order. If none are
+// healthy, all endpoints are returned so gRPC keeps trying to connect.
+func (b *endpointBalancer) addressesLocked() []resolver.Address {
+	var addrs, all []resolver.Address
+	for _, endpoint := range b.current {
+		addr := resolver.Address{Addr: endpoint}
+		if host, _, err := net.SplitHostPort(endpoint); err == nil {
+			addr.ServerName = host
+		}
+		all = append(all, addr)
+		if b.healthy[endpoint] {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return all
+	}
+	return addrs
+}
+
+func (b *endpointBalancer) updateStateLocked() {
+	for endpoint, healthy := range b.healthy {
+		var value float64
+		if healthy {
+			value = 1
+		}
+		b.metricsHandler.WithTags(metrics.EndpointTags(endpoint)).Gauge(metrics.ClientEndpointHealthy).Update(value)
+	}
+	addrs := b.addressesLocked()
+	if b.policy == LoadBalancingPolicyPickFirst && len(addrs) > 0 && addrs[0].Addr != b.preferred {
+		if b.preferred != "" {
+			b.logger.Warn("Failing over to endpoint", "From", b.preferred, "To", addrs[0].Addr)
+		}
+		b.preferred = addrs[0].Addr
+	}
+	if b.cc != nil {
+		// Errors here only mean the balancer has not connected yet, gRPC keeps
+		// retrying on its own
+		_ = b.cc.UpdateState(resolver.State{Addresses: addrs})
+	}
+}
+
+// Scheme implements resolver.Builder.
+func (b *endpointBalancer) Scheme() string {
+	return b.scheme
+}
+
+// Build implements resolver.Builder. gRPC may build the resolver again after
+// the connection leaves idle mode, in which case the latest addresses are
+// published to the new resolver connection.
+func (b *endpointBalancer) Build(
+	target resolver.Target,
+	cc resolver.ClientConn,
+	opts resolver.BuildOptions,
+) (resolver.Resolver, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.cc = cc
+	b.updateStateLocked()
+	return &endpointResolver{balancer: b, cc: cc}, nil
+}
+
+// ResolveNow implements resolver.Resolver. Addresses are refreshed on the
+// health check interval, so this is a no-op.
+func (r *endpointResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+// Close implements resolver.Resolver.
+func (r *endpointResolver) Close() {
+	r.balancer.lock.Lock()
+	defer r.balancer.lock.Unlock()
+	if r.balancer.cc == r.cc {
+		r.balancer.cc = nil
+	}
+}
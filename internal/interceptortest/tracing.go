@@ -143,8 +143,13 @@ func AssertSpanPropagation(t *testing.T, tracer TestTracer) {
 			Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "StartActivity", Name: "testActivityLocal"}),
 				Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "RunActivity", Name: "testActivityLocal"})))),
 		// This is the workflow that gets started from the nexus workflow run operation.
-		Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "RunWorkflow", Name: "testWaitForCancelWorkflow"})),
+		Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "RunWorkflow", Name: "testWaitForCancelWorkflow"}),
+			Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "Await"}))),
 		Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "RunWorkflow", Name: "testWorkflow"}),
+			Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "Await"})),
+			Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "Sleep", Name: "1ms"}),
+				Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "StartTimer", Name: "Sleep"}))),
+			Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "SideEffect"})),
 			Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "StartActivity", Name: "testActivity"}),
 				Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "RunActivity", Name: "testActivity"}))),
 			Span(tracer.SpanName(&interceptor.TracerStartSpanOptions{Operation: "StartActivity", Name: "testActivityLocal"}),
@@ -186,6 +191,13 @@ func testWorkflow(ctx workflow.Context) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := workflow.Sleep(ctx, time.Millisecond); err != nil {
+		return nil, err
+	}
+	var sideEffect string
+	if err := workflow.SideEffect(ctx, func(workflow.Context) interface{} { return "side-effect" }).Get(&sideEffect); err != nil {
+		return nil, err
+	}
 	// Run code
 	ret, err := workflowInternal(ctx, false)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/protobuf/proto"
@@ -136,6 +137,8 @@ type (
 		priority               *commonpb.Priority
 		retryPolicy            *RetryPolicy
 		activityRunID          string
+		heartbeatCount         atomic.Int64
+		lastHeartbeatTime      atomic.Int64 // Unix nanoseconds
 	}
 
 	// context.WithValue need this type instead of basic type string to avoid lint error
@@ -409,10 +412,33 @@ func (a *activityEnvironmentInterceptor) RecordHeartbeat(ctx context.Context, de
 		}
 	}
 
+	a.env.heartbeatCount.Add(1)
+	a.env.lastHeartbeatTime.Store(time.Now().UnixNano())
 	// Heartbeat error is logged inside ServiceInvoker.internalHeartBeat
 	_ = a.env.serviceInvoker.Heartbeat(ctx, data, false)
 }
 
+// recordExecutionMetrics records metrics for an activity execution that just
+// returned, derived from its info and the heartbeats it recorded.
+func (env *activityEnvironment) recordExecutionMetrics(info ActivityInfo, metricsHandler metrics.Handler) {
+	now := time.Now()
+	metricsHandler.WithTags(metrics.AttemptTags(info.Attempt)).Counter(metrics.ActivityExecutionAttemptCounter).Inc(1)
+
+	if count := env.heartbeatCount.Load(); count > 0 {
+		metricsHandler.Counter(metrics.ActivityHeartbeatCounter).Inc(count)
+		lastHeartbeatTime := time.Unix(0, env.lastHeartbeatTime.Load())
+		metricsHandler.Timer(metrics.ActivityLastHeartbeatAge).Record(now.Sub(lastHeartbeatTime))
+	}
+
+	if info.StartToCloseTimeout > 0 && !info.StartedTime.IsZero() {
+		elapsed := now.Sub(info.StartedTime)
+		utilization := float64(elapsed) / float64(info.StartToCloseTimeout)
+		metricsHandler.WithTags(metrics.TimeoutBudgetUtilizationTags(utilization)).
+			Counter(metrics.ActivityTimeoutBudgetUtilization).Inc(1)
+		metricsHandler.Timer(metrics.ActivityTimeoutBudgetRemaining).Record(max(info.StartToCloseTimeout-elapsed, 0))
+	}
+}
+
 func (a *activityEnvironmentInterceptor) HasHeartbeatDetails(ctx context.Context) bool {
 	return a.env.heartbeatDetails != nil
 }
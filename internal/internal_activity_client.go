@@ -128,7 +128,11 @@ type (
 	//
 	// Exposed as: [go.temporal.io/sdk/client.ListActivitiesOptions]
 	ClientListActivitiesOptions struct {
+		// Query filters the activities with a SQL-like query.
 		Query string
+		// TypedQuery filters the activities with a query built from typed search attribute keys. Cannot be set with
+		// Query.
+		TypedQuery VisibilityQuery
 	}
 
 	// ClientListActivitiesResult contains the result of the ListActivities call.
@@ -147,6 +151,9 @@ type (
 	// Exposed as: [go.temporal.io/sdk/client.CountActivitiesOptions]
 	ClientCountActivitiesOptions struct {
 		Query string
+		// TypedQuery filters the counted activities with a query built from typed search attribute keys. Cannot be set
+		// with Query.
+		TypedQuery VisibilityQuery
 	}
 
 	// ClientCountActivitiesResult contains the result of the CountActivities call.
@@ -476,6 +483,10 @@ func (wc *WorkflowClient) GetActivityHandle(options ClientGetActivityHandleOptio
 }
 
 func (wc *WorkflowClient) ListActivities(ctx context.Context, options ClientListActivitiesOptions) (ClientListActivitiesResult, error) {
+	query, err := visibilityQueryOrString(options.Query, options.TypedQuery)
+	if err != nil {
+		return ClientListActivitiesResult{}, err
+	}
 	return ClientListActivitiesResult{
 		Results: func(yield func(*ClientActivityExecutionInfo, error) bool) {
 			if err := wc.ensureInitialized(ctx); err != nil {
@@ -485,7 +496,7 @@ func (wc *WorkflowClient) ListActivities(ctx context.Context, options ClientList
 
 			request := &workflowservice.ListActivityExecutionsRequest{
 				Namespace: wc.namespace,
-				Query:     options.Query,
+				Query:     query,
 			}
 
 			for {
@@ -530,12 +541,16 @@ func (wc *WorkflowClient) getListActivitiesPage(ctx context.Context, request *wo
 }
 
 func (wc *WorkflowClient) CountActivities(ctx context.Context, options ClientCountActivitiesOptions) (*ClientCountActivitiesResult, error) {
+	query, err := visibilityQueryOrString(options.Query, options.TypedQuery)
+	if err != nil {
+		return nil, err
+	}
 	grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
 	defer cancel()
 
 	request := &workflowservice.CountActivityExecutionsRequest{
 		Namespace: wc.namespace,
-		Query:     options.Query,
+		Query:     query,
 	}
 	resp, err := wc.WorkflowService().CountActivityExecutions(grpcCtx, request)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	commandpb "go.temporal.io/api/command/v1"
@@ -155,11 +156,13 @@ type (
 		enableLoggingInReplay bool // flag to indicate if workflow should enable logging in replay mode
 
 		metricsHandler           metrics.Handler
+		queryMetricsHandler      metrics.Handler // Not replay aware since queries are never replayed
 		registry                 *registry
 		dataConverter            converter.DataConverter
 		failureConverter         converter.FailureConverter
 		contextPropagators       []ContextPropagator
 		deadlockDetectionTimeout time.Duration
+		deadlockedCoroutines     *atomic.Int64
 		sdkFlags                 *sdkFlags
 		sdkVersionUpdated        bool
 		sdkVersion               string
@@ -222,6 +225,7 @@ func newWorkflowExecutionEventHandler(
 	failureConverter converter.FailureConverter,
 	contextPropagators []ContextPropagator,
 	deadlockDetectionTimeout time.Duration,
+	deadlockedCoroutines *atomic.Int64,
 	capabilities *workflowservice.GetSystemInfoResponse_Capabilities,
 ) workflowExecutionEventHandler {
 	wfCtx := converter.WorkflowSerializationContext{
@@ -247,6 +251,7 @@ func newWorkflowExecutionEventHandler(
 		failureConverter:             failureConverter,
 		contextPropagators:           contextPropagators,
 		deadlockDetectionTimeout:     deadlockDetectionTimeout,
+		deadlockedCoroutines:         deadlockedCoroutines,
 		protocols:                    protocol.NewRegistry(),
 		mutableSideEffectCallCounter: make(map[string]int),
 		sdkFlags:                     newSDKFlagSet(capabilities),
@@ -266,6 +271,7 @@ func newWorkflowExecutionEventHandler(
 	if metricsHandler != nil {
 		context.metricsHandler = metrics.NewReplayAwareHandler(&context.isReplay, metricsHandler).
 			WithTags(metrics.WorkflowTags(workflowInfo.WorkflowType.Name))
+		context.queryMetricsHandler = metricsHandler.WithTags(metrics.WorkflowTags(workflowInfo.WorkflowType.Name))
 	}
 
 	return &workflowExecutionEventHandlerImpl{context, nil}
@@ -885,6 +891,7 @@ func (wc *workflowEnvironmentImpl) NewTimer(d time.Duration, options TimerOption
 
 	command := wc.commandsHelper.startTimer(startTimerAttr, options, wc.GetDataConverter())
 	command.setData(&scheduledTimer{callback: callback})
+	wc.metricsHandler.Counter(metrics.WorkflowTimerStartedCounter).Inc(1)
 
 	wc.logger.Debug("NewTimer",
 		tagTimerID, startTimerAttr.GetTimerId(),
@@ -902,6 +909,7 @@ func (wc *workflowEnvironmentImpl) RequestCancelTimer(timerID TimerID) {
 		}
 		timer.handle(nil, ErrCanceled)
 	}
+	wc.metricsHandler.Counter(metrics.WorkflowTimerCanceledCounter).Inc(1)
 	wc.logger.Debug("RequestCancelTimer", tagTimerID, timerID)
 }
 
@@ -948,6 +956,7 @@ func (wc *workflowEnvironmentImpl) GetVersion(changeID string, minSupported, max
 				updateSearchAttribute = false
 			}
 			wc.commandsHelper.recordVersionMarker(changeID, version, wc.GetDataConverter(), updateSearchAttribute)
+			wc.metricsHandler.Counter(metrics.WorkflowVersionMarkerCounter).Inc(1)
 			if updateSearchAttribute {
 				_ = wc.UpsertSearchAttributes(changeVersionSA)
 			}
@@ -1450,6 +1459,21 @@ func (weh *workflowExecutionEventHandlerImpl) ProcessQuery(
 	queryType string,
 	queryArgs *commonpb.Payloads,
 	header *commonpb.Header,
+) (*commonpb.Payloads, error) {
+	start := time.Now()
+	result, err := weh.processQuery(queryType, queryArgs, header)
+	weh.queryMetricsHandler.Timer(metrics.WorkflowQueryExecutionLatency).Record(time.Since(start))
+	weh.queryMetricsHandler.Counter(metrics.WorkflowQueryHandledCounter).Inc(1)
+	if err != nil {
+		weh.queryMetricsHandler.Counter(metrics.WorkflowQueryFailedCounter).Inc(1)
+	}
+	return result, err
+}
+
+func (weh *workflowExecutionEventHandlerImpl) processQuery(
+	queryType string,
+	queryArgs *commonpb.Payloads,
+	header *commonpb.Header,
 ) (*commonpb.Payloads, error) {
 	switch queryType {
 	case QueryTypeStackTrace:
@@ -1608,6 +1632,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleTimerFired(event *historypb.
 		return
 	}
 
+	weh.metricsHandler.Counter(metrics.WorkflowTimerFiredCounter).Inc(1)
 	timer.handle(nil, nil)
 }
 
@@ -1804,6 +1829,7 @@ func (weh *workflowExecutionEventHandlerImpl) ProcessLocalActivityResult(lar *lo
 func (weh *workflowExecutionEventHandlerImpl) handleWorkflowExecutionSignaled(
 	attributes *historypb.WorkflowExecutionSignaledEventAttributes,
 ) error {
+	weh.metricsHandler.Counter(metrics.WorkflowSignalReceivedCounter).Inc(1)
 	return weh.signalHandler(attributes.GetSignalName(), attributes.Input, attributes.Header)
 }
 
@@ -1836,6 +1862,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleStartChildWorkflowExecutionF
 		enumspb.RETRY_STATE_NON_RETRYABLE_FAILURE,
 		causeErr,
 	)
+	weh.metricsHandler.Counter(metrics.WorkflowChildFailedCounter).Inc(1)
 	childWorkflow.handleFailedToStart(nil, err)
 	return nil
 }
@@ -1854,6 +1881,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleChildWorkflowExecutionStarte
 		ID:    childWorkflowID,
 		RunID: childRunID,
 	}
+	weh.metricsHandler.Counter(metrics.WorkflowChildStartedCounter).Inc(1)
 	childWorkflow.startedCallback(childWorkflowExecution, nil)
 
 	return nil
@@ -1891,6 +1919,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleChildWorkflowExecutionFailed
 		attributes.GetRetryState(),
 		childWorkflow.failureConverter.FailureToError(attributes.GetFailure()),
 	)
+	weh.metricsHandler.Counter(metrics.WorkflowChildFailedCounter).Inc(1)
 	childWorkflow.handle(nil, childWorkflowExecutionError)
 	return nil
 }
@@ -1938,6 +1967,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleChildWorkflowExecutionTimedO
 		attributes.GetRetryState(),
 		NewTimeoutError("Child workflow timeout", enumspb.TIMEOUT_TYPE_START_TO_CLOSE, nil),
 	)
+	weh.metricsHandler.Counter(metrics.WorkflowChildFailedCounter).Inc(1)
 	childWorkflow.handle(nil, childWorkflowExecutionError)
 	return nil
 }
@@ -1961,6 +1991,7 @@ func (weh *workflowExecutionEventHandlerImpl) handleChildWorkflowExecutionTermin
 		enumspb.RETRY_STATE_NON_RETRYABLE_FAILURE,
 		newTerminatedError(),
 	)
+	weh.metricsHandler.Counter(metrics.WorkflowChildFailedCounter).Inc(1)
 	childWorkflow.handle(nil, childWorkflowExecutionError)
 	return nil
 }
@@ -2202,12 +2233,66 @@ func (weh *workflowExecutionEventHandlerImpl) protocolConstructorForMessage(
 	switch protoName {
 	case updateProtocolV1:
 		return func() protocol.Instance {
-			return newUpdateProtocol(msg.ProtocolInstanceId, weh.updateHandler, weh)
+			return newUpdateProtocol(msg.ProtocolInstanceId, weh.meteredUpdateHandler, weh)
 		}, nil
 	}
 	return nil, fmt.Errorf("unsupported protocol: %v", protoName)
 }
 
+// meteredUpdateHandler schedules the update with callbacks that record update
+// metrics.
+func (weh *workflowExecutionEventHandlerImpl) meteredUpdateHandler(
+	name string,
+	id string,
+	args *commonpb.Payloads,
+	header *commonpb.Header,
+	callbacks UpdateCallbacks,
+) {
+	weh.updateHandler(name, id, args, header, &meteredUpdateCallbacks{
+		UpdateCallbacks: callbacks,
+		metricsHandler:  weh.metricsHandler,
+		now:             weh.Now,
+	})
+}
+
+// meteredUpdateCallbacks records update metrics before delegating to the
+// wrapped callbacks.
+type meteredUpdateCallbacks struct {
+	UpdateCallbacks
+	metricsHandler metrics.Handler
+	// now returns the workflow time. The update may have been accepted during
+	// replay, so the latency is measured between the workflow times of the tasks
+	// that accepted and completed it, which are the same on replay and on the
+	// same clock, rather than against the local time.
+	now          func() time.Time
+	acceptedTime time.Time
+}
+
+func (c *meteredUpdateCallbacks) setConverters(dc converter.DataConverter, fc converter.FailureConverter) {
+	if cs, ok := c.UpdateCallbacks.(updateConverterSetter); ok {
+		cs.setConverters(dc, fc)
+	}
+}
+
+func (c *meteredUpdateCallbacks) Accept() {
+	c.acceptedTime = c.now()
+	c.metricsHandler.Counter(metrics.WorkflowUpdateAcceptedCounter).Inc(1)
+	c.UpdateCallbacks.Accept()
+}
+
+func (c *meteredUpdateCallbacks) Reject(err error) {
+	c.metricsHandler.Counter(metrics.WorkflowUpdateRejectedCounter).Inc(1)
+	c.UpdateCallbacks.Reject(err)
+}
+
+func (c *meteredUpdateCallbacks) Complete(success interface{}, err error) {
+	if !c.acceptedTime.IsZero() {
+		c.metricsHandler.Timer(metrics.WorkflowUpdateExecutionLatency).Record(c.now().Sub(c.acceptedTime))
+	}
+	c.metricsHandler.Counter(metrics.WorkflowUpdateCompletedCounter).Inc(1)
+	c.UpdateCallbacks.Complete(success, err)
+}
+
 func convertContinueAsNewSuggestedReasonsFromProto(
 	reasons []enumspb.SuggestContinueAsNewReason,
 ) []ContinueAsNewSuggestedReason {
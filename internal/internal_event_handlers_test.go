@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	commandpb "go.temporal.io/api/command/v1"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
@@ -14,6 +15,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
 	iconverter "go.temporal.io/sdk/internal/converter"
 	"go.temporal.io/sdk/internal/protocol"
 )
@@ -524,3 +526,52 @@ func TestUpdateEventsPanic(t *testing.T) {
 		}, false, false)
 	})
 }
+
+func TestChildWorkflowFailedMetrics(t *testing.T) {
+	capturing := metrics.NewCapturingHandler()
+	weh := &workflowExecutionEventHandlerImpl{
+		workflowEnvironmentImpl: &workflowEnvironmentImpl{
+			commandsHelper: newCommandsHelper(),
+			metricsHandler: capturing,
+		},
+	}
+	startChild := func(workflowID string) *error {
+		command, err := weh.commandsHelper.startChildWorkflowExecution(&commandpb.StartChildWorkflowExecutionCommandAttributes{WorkflowId: workflowID}, nil)
+		require.NoError(t, err)
+		var childErr error
+		command.setData(&scheduledChildWorkflow{
+			resultCallback:   func(_ *commonpb.Payloads, err error) { childErr = err },
+			failureConverter: GetDefaultFailureConverter(),
+		})
+		weh.commandsHelper.getCommands(true)
+		weh.commandsHelper.handleStartChildWorkflowExecutionInitiated(workflowID)
+		weh.commandsHelper.handleChildWorkflowExecutionStarted(workflowID)
+		return &childErr
+	}
+
+	// Timed out and terminated children are counted as failed
+	timedOutErr := startChild("timed-out")
+	require.NoError(t, weh.handleChildWorkflowExecutionTimedOut(&historypb.HistoryEvent{
+		Attributes: &historypb.HistoryEvent_ChildWorkflowExecutionTimedOutEventAttributes{
+			ChildWorkflowExecutionTimedOutEventAttributes: &historypb.ChildWorkflowExecutionTimedOutEventAttributes{
+				WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "timed-out"},
+			},
+		},
+	}))
+	terminatedErr := startChild("terminated")
+	require.NoError(t, weh.handleChildWorkflowExecutionTerminated(&historypb.HistoryEvent{
+		Attributes: &historypb.HistoryEvent_ChildWorkflowExecutionTerminatedEventAttributes{
+			ChildWorkflowExecutionTerminatedEventAttributes: &historypb.ChildWorkflowExecutionTerminatedEventAttributes{
+				WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "terminated"},
+			},
+		},
+	}))
+	var timeoutErr *TimeoutError
+	require.ErrorAs(t, *timedOutErr, &timeoutErr)
+	var terminated *TerminatedError
+	require.ErrorAs(t, *terminatedErr, &terminated)
+	counters := capturing.Counters()
+	require.Len(t, counters, 1)
+	require.Equal(t, metrics.WorkflowChildFailedCounter, counters[0].Name)
+	require.Equal(t, int64(2), counters[0].Value())
+}
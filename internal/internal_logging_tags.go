@@ -41,4 +41,5 @@ const (
 	tagPayloadUploadCount           = "PayloadUploadCount"
 	tagPayloadUploadSize            = "PayloadUploadSize"
 	tagPayloadUploadDuration        = "PayloadUploadDuration"
+	tagEndpoint                     = "Endpoint"
 )
@@ -64,6 +64,7 @@ type nexusTaskHandler struct {
 	logger           log.Logger
 	metricsHandler   metrics.Handler
 	registry         *registry
+	middleware       []NexusMiddleware
 }
 
 func newNexusTaskHandler(
@@ -77,6 +78,7 @@ func newNexusTaskHandler(
 	logger log.Logger,
 	metricsHandler metrics.Handler,
 	registry *registry,
+	middleware []NexusMiddleware,
 ) *nexusTaskHandler {
 	return &nexusTaskHandler{
 		nexusHandler:     nexusHandler,
@@ -89,6 +91,7 @@ func newNexusTaskHandler(
 		client:           client,
 		metricsHandler:   metricsHandler,
 		registry:         registry,
+		middleware:       middleware,
 	}
 }
 
@@ -154,9 +157,9 @@ func (h *nexusTaskHandler) execute(nctx *NexusOperationContext, task *workflowse
 
 	switch req := task.GetRequest().GetVariant().(type) {
 	case *nexuspb.Request_StartOperation:
-		return h.handleStartOperation(ctx, nctx, req.StartOperation, header)
+		return h.handleStartOperation(ctx, nctx, req.StartOperation, header, task.GetRequest().GetEndpoint())
 	case *nexuspb.Request_CancelOperation:
-		return h.handleCancelOperation(ctx, nctx, req.CancelOperation, header)
+		return h.handleCancelOperation(ctx, nctx, req.CancelOperation, header, task.GetRequest().GetEndpoint())
 	default:
 		return nil, nexus.NewHandlerErrorf(
 			nexus.HandlerErrorTypeNotImplemented,
@@ -170,6 +173,7 @@ func (h *nexusTaskHandler) handleStartOperation(
 	nctx *NexusOperationContext,
 	req *nexuspb.StartOperationRequest,
 	header nexus.Header,
+	endpoint string,
 ) (*nexuspb.Response, *nexus.HandlerError, error) {
 	serializer := &payloadSerializer{
 		converter: h.dataConverter,
@@ -216,6 +220,7 @@ func (h *nexusTaskHandler) handleStartOperation(
 	})
 	var opres nexus.HandlerStartOperationResult[any]
 	var err error
+	var middlewareErr *nexus.HandlerError
 	var panic bool
 	func() {
 		defer func() {
@@ -231,8 +236,23 @@ func (h *nexusTaskHandler) handleStartOperation(
 				nctx.log.Error("Panic captured while handling Nexus task", tagStackTrace, string(debug.Stack()), tagError, err)
 			}
 		}()
+		// Middleware is user code too, so it runs under the same panic handling as the handler.
+		ctx, middlewareErr = applyNexusMiddleware(ctx, nctx, h.middleware, &NexusMiddlewareRequest{
+			Service:      req.GetService(),
+			Operation:    req.GetOperation(),
+			Endpoint:     endpoint,
+			Header:       header,
+			Links:        nexusLinks,
+			StartOptions: &startOptions,
+		})
+		if middlewareErr != nil {
+			return
+		}
 		opres, err = h.nexusHandler.StartOperation(ctx, req.GetService(), req.GetOperation(), input, startOptions)
 	}()
+	if middlewareErr != nil {
+		return nil, middlewareErr, nil
+	}
 	if ctx.Err() != nil {
 		if !panic {
 			nctx.log.Error("Context error while processing Nexus task", tagError, ctx.Err())
@@ -348,14 +368,21 @@ func (h *nexusTaskHandler) handleStartOperation(
 	}
 }
 
-func (h *nexusTaskHandler) handleCancelOperation(ctx context.Context, nctx *NexusOperationContext, req *nexuspb.CancelOperationRequest, header nexus.Header) (*nexuspb.Response, *nexus.HandlerError, error) {
+func (h *nexusTaskHandler) handleCancelOperation(ctx context.Context, nctx *NexusOperationContext, req *nexuspb.CancelOperationRequest, header nexus.Header, endpoint string) (*nexuspb.Response, *nexus.HandlerError, error) {
 	cancelOptions := nexus.CancelOperationOptions{Header: header}
 	ctx = nexus.WithHandlerContext(ctx, nexus.HandlerInfo{
 		Service:   req.GetService(),
 		Operation: req.GetOperation(),
 		Header:    header,
 	})
+	token := req.GetOperationToken()
+	if token == "" {
+		// Support servers older than 1.27.0.
+		//lint:ignore SA1019 ignore deprecated
+		token = req.GetOperationId()
+	}
 	var err error
+	var middlewareErr *nexus.HandlerError
 	var panic bool
 	func() {
 		defer func() {
@@ -371,14 +398,22 @@ func (h *nexusTaskHandler) handleCancelOperation(ctx context.Context, nctx *Nexu
 				nctx.log.Error("Panic captured while handling Nexus task", tagStackTrace, string(debug.Stack()), tagError, err)
 			}
 		}()
-		token := req.GetOperationToken()
-		if token == "" {
-			// Support servers older than 1.27.0.
-			//lint:ignore SA1019 ignore deprecated
-			token = req.GetOperationId()
+		ctx, middlewareErr = applyNexusMiddleware(ctx, nctx, h.middleware, &NexusMiddlewareRequest{
+			Service:        req.GetService(),
+			Operation:      req.GetOperation(),
+			Endpoint:       endpoint,
+			Header:         header,
+			CancelOptions:  &cancelOptions,
+			OperationToken: token,
+		})
+		if middlewareErr != nil {
+			return
 		}
 		err = h.nexusHandler.CancelOperation(ctx, req.GetService(), req.GetOperation(), token, cancelOptions)
 	}()
+	if middlewareErr != nil {
+		return nil, middlewareErr, nil
+	}
 	if ctx.Err() != nil {
 		if !panic {
 			nctx.log.Error("Context error while processing Nexus task", tagError, ctx.Err())
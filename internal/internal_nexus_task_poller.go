@@ -62,7 +62,9 @@ func (ntp *nexusTaskPoller) pollNexusTaskQueue(ctx context.Context, request *wor
 	ntp.numPollerMetric.increment()
 	defer ntp.numPollerMetric.decrement()
 
-	return ntp.service.PollNexusTaskQueue(ctx, request)
+	response, err := ntp.service.PollNexusTaskQueue(ctx, request)
+	ntp.pollTimeTracker.recordPollCompletion(metrics.PollerTypeNexusTask, err)
+	return response, err
 }
 
 func (ntp *nexusTaskPoller) poll(ctx context.Context) (taskForWorker, error) {
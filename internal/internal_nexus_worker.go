@@ -37,6 +37,7 @@ func newNexusWorker(opts nexusWorkerOptions) (*nexusWorker, error) {
 			opts.executionParameters.Logger,
 			opts.executionParameters.MetricsHandler,
 			opts.registry,
+			opts.executionParameters.NexusMiddleware,
 		),
 		opts.workflowService,
 		params,
@@ -179,6 +179,10 @@ func (sc *scheduleClient) GetHandle(ctx context.Context, scheduleID string) Sche
 }
 
 func (sc *scheduleClient) List(ctx context.Context, options ScheduleListOptions) (ScheduleListIterator, error) {
+	query, err := visibilityQueryOrString(options.Query, options.TypedQuery)
+	if err != nil {
+		return nil, err
+	}
 	paginate := func(nextToken []byte) (*workflowservice.ListSchedulesResponse, error) {
 		grpcCtx, cancel := newGRPCContext(ctx, defaultGrpcRetryParameters(ctx))
 		defer cancel()
@@ -186,7 +190,7 @@ func (sc *scheduleClient) List(ctx context.Context, options ScheduleListOptions)
 			Namespace:       sc.workflowClient.namespace,
 			MaximumPageSize: int32(options.PageSize),
 			NextPageToken:   nextToken,
-			Query:           options.Query,
+			Query:           query,
 		}
 
 		return sc.workflowClient.workflowService.ListSchedules(grpcCtx, request)
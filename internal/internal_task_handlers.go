@@ -94,6 +94,7 @@ type (
 	activityTask struct {
 		task   *workflowservice.PollActivityTaskQueueResponse
 		permit *SlotPermit
+		slot   *taskSlot
 	}
 
 	// workflowExecutionContextImpl is the cached workflow state for sticky execution
@@ -139,6 +140,7 @@ type (
 		contextPropagators        []ContextPropagator
 		cache                     *WorkerCache
 		deadlockDetectionTimeout  time.Duration
+		deadlockedCoroutines      *atomic.Int64
 		capabilities              *workflowservice.GetSystemInfoResponse_Capabilities
 	}
 
@@ -164,6 +166,7 @@ type (
 		versionStamp                     *commonpb.WorkerVersionStamp
 		deployment                       *deploymentpb.Deployment
 		workerDeploymentOptions          *deploymentpb.WorkerDeploymentOptions
+		activityRateLimit                ActivityRateLimitOptions
 	}
 
 	// history wrapper method to help information about events.
@@ -578,6 +581,7 @@ func newWorkflowTaskHandler(params workerExecutionParameters, ppMgr pressurePoin
 		contextPropagators:        params.ContextPropagators,
 		cache:                     params.cache,
 		deadlockDetectionTimeout:  params.DeadlockDetectionTimeout,
+		deadlockedCoroutines:      params.deadlockedCoroutines,
 		capabilities:              params.capabilities,
 	}
 }
@@ -689,6 +693,7 @@ func (w *workflowExecutionContextImpl) createEventHandler() {
 		w.wth.failureConverter,
 		w.wth.contextPropagators,
 		w.wth.deadlockDetectionTimeout,
+		w.wth.deadlockedCoroutines,
 		w.wth.capabilities,
 	)
 
@@ -2075,6 +2080,7 @@ func newActivityTaskHandlerWithCustomProvider(
 		namespace:                        params.Namespace,
 		defaultHeartbeatThrottleInterval: params.DefaultHeartbeatThrottleInterval,
 		maxHeartbeatThrottleInterval:     params.MaxHeartbeatThrottleInterval,
+		activityRateLimit:                params.ActivityRateLimit,
 		versionStamp: &commonpb.WorkerVersionStamp{
 			BuildId:       params.getBuildID(),
 			UseVersioning: params.UseBuildIDForVersioning,
@@ -2257,7 +2263,13 @@ func newServiceInvoker(
 }
 
 // Execute executes an implementation of the activity.
-func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice.PollActivityTaskQueueResponse) (result interface{}, err error) {
+func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice.PollActivityTaskQueueResponse) (interface{}, error) {
+	return ath.execute(taskQueue, t, nil)
+}
+
+// execute executes an implementation of the activity. The slot of the task, if
+// given, is given back while the activity waits on its rate limit.
+func (ath *activityTaskHandlerImpl) execute(taskQueue string, t *workflowservice.PollActivityTaskQueueResponse, slot *taskSlot) (result interface{}, err error) {
 	traceLog(func() {
 		if t.WorkflowExecution.GetWorkflowId() == "" {
 			ath.logger.Debug("Processing new standalone activity task",
@@ -2354,7 +2366,15 @@ func (ath *activityTaskHandlerImpl) Execute(taskQueue string, t *workflowservice
 	ctx, dlCancelFunc := context.WithDeadline(ctx, info.deadline)
 	defer dlCancelFunc()
 
-	output, err := activityImplementation.Execute(ctx, t.Input)
+	// Throttled activities are delayed locally, counting towards their timeouts
+	var output *commonpb.Payloads
+	err = ath.waitActivityRateLimit(ctx, t, slot, invoker, heartbeatThrottleInterval, metricsHandler)
+	if err == nil {
+		output, err = activityImplementation.Execute(ctx, t.Input)
+		if err != ErrActivityResultPending {
+			info.recordExecutionMetrics(GetActivityInfo(ctx), metricsHandler)
+		}
+	}
 	// Check if context canceled at a higher level before we cancel it ourselves
 
 	// Cancels that don't originate from the server will have separate cancel reasons, like
@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -603,6 +604,54 @@ func (t *TaskHandlersTestSuite) TestWorkflowTask_BinaryChecksum() {
 	t.Equal(getBinaryChecksum(), checksums[2])
 }
 
+func (t *TaskHandlersTestSuite) TestWorkflowTask_WorkflowMetrics() {
+	taskQueue := "tq1"
+	testEvents := []*historypb.HistoryEvent{
+		createTestEventWorkflowExecutionStarted(1, &historypb.WorkflowExecutionStartedEventAttributes{TaskQueue: &taskqueuepb.TaskQueue{Name: taskQueue}}),
+		createTestEventWorkflowTaskScheduled(2, &historypb.WorkflowTaskScheduledEventAttributes{TaskQueue: &taskqueuepb.TaskQueue{Name: taskQueue}}),
+		createTestEventWorkflowTaskStarted(3),
+		createTestEventWorkflowTaskCompleted(4, &historypb.WorkflowTaskCompletedEventAttributes{ScheduledEventId: 2}),
+		createTestEventTimerStarted(5, 5),
+		createTestEventWorkflowExecutionSignaled(6, "signal"),
+		createTestEventTimerFired(7, 5),
+		createTestEventWorkflowTaskScheduled(8, &historypb.WorkflowTaskScheduledEventAttributes{TaskQueue: &taskqueuepb.TaskQueue{Name: taskQueue}}),
+		createTestEventWorkflowTaskStarted(9),
+		createTestEventWorkflowTaskCompleted(10, &historypb.WorkflowTaskCompletedEventAttributes{ScheduledEventId: 8}),
+		createTestEventTimerStarted(11, 11),
+		createTestEventWorkflowExecutionSignaled(12, "signal"),
+		createTestEventTimerFired(13, 11),
+		createTestEventWorkflowTaskScheduled(14, &historypb.WorkflowTaskScheduledEventAttributes{TaskQueue: &taskqueuepb.TaskQueue{Name: taskQueue}}),
+		createTestEventWorkflowTaskStarted(15),
+	}
+	task := createWorkflowTask(testEvents, 9, "BinaryChecksumWorkflow")
+	params := t.getTestWorkerExecutionParams()
+	metricsHandler := metrics.NewCapturingHandler()
+	params.MetricsHandler = metricsHandler
+	taskHandler := newWorkflowTaskHandler(params, nil, t.registry)
+	wftask := workflowTask{task: task}
+	wfctx := t.mustWorkflowContextImpl(&wftask, taskHandler)
+	_, err := taskHandler.ProcessWorkflowTask(&wftask, wfctx, nil)
+	t.NoError(err)
+	_, err = wfctx.getEventHandler().ProcessQuery(QueryTypeStackTrace, nil, nil)
+	t.NoError(err)
+	wfctx.Unlock(err)
+
+	// Only events after the last completed workflow task are counted
+	counts := map[string]int64{}
+	for _, counter := range metricsHandler.Counters() {
+		if counter.Tags[metrics.WorkflowTypeNameTagName] == "BinaryChecksumWorkflow" {
+			counts[counter.Name] += counter.Value()
+		}
+	}
+	t.Equal(int64(1), counts[metrics.WorkflowSignalReceivedCounter])
+	t.Equal(int64(1), counts[metrics.WorkflowTimerFiredCounter])
+	t.Equal(int64(0), counts[metrics.WorkflowTimerStartedCounter])
+	t.Equal(int64(1), counts[metrics.WorkflowQueryHandledCounter])
+	t.Equal(int64(0), counts[metrics.WorkflowQueryFailedCounter])
+	// clean up workflow left in cache
+	params.cache.getWorkflowCache().Delete(task.WorkflowExecution.RunId)
+}
+
 func (t *TaskHandlersTestSuite) TestRespondsToWFTWithWorkerBinaryID() {
 	taskQueue := "tq1"
 	workerBuildID := "yaaaay"
@@ -2775,3 +2824,212 @@ func TestHistoryIteratorMaxEventID(t *testing.T) {
 	require.Error(t, err)
 
 }
+
+type recordingActivityRateLimiter struct {
+	keys []string
+	wait time.Duration
+}
+
+func (r *recordingActivityRateLimiter) Wait(ctx context.Context, key string) error {
+	r.keys = append(r.keys, key)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(r.wait):
+		return nil
+	}
+}
+
+func (t *TaskHandlersTestSuite) TestActivityExecutionRateLimited() {
+	a := &testActivityDeadline{logger: t.logger}
+	registry := t.registry
+	registry.addActivityWithLock(a.ActivityType().Name, a)
+
+	mockCtrl := gomock.NewController(t.T())
+	mockService := workflowservicemock.NewMockWorkflowServiceClient(mockCtrl)
+	client := WorkflowClient{workflowService: mockService}
+
+	tenant, err := converter.GetDefaultDataConverter().ToPayload("tenant1")
+	t.NoError(err)
+	newTask := func(startToClose time.Duration) *workflowservice.PollActivityTaskQueueResponse {
+		now := time.Now()
+		return &workflowservice.PollActivityTaskQueueResponse{
+			Attempt:                1,
+			TaskToken:              []byte("token"),
+			WorkflowExecution:      &commonpb.WorkflowExecution{WorkflowId: "wID", RunId: "rID"},
+			ActivityType:           &commonpb.ActivityType{Name: "test"},
+			ActivityId:             uuid.NewString(),
+			ScheduledTime:          timestamppb.New(now),
+			ScheduleToCloseTimeout: durationpb.New(startToClose),
+			StartedTime:            timestamppb.New(now),
+			StartToCloseTimeout:    durationpb.New(startToClose),
+			WorkflowType:           &commonpb.WorkflowType{Name: "wType"},
+			WorkflowNamespace:      "namespace",
+			Header:                 &commonpb.Header{Fields: map[string]*commonpb.Payload{"tenant": tenant}},
+		}
+	}
+
+	// Throttled tasks are delayed, then executed
+	limiter := &recordingActivityRateLimiter{wait: 10 * time.Millisecond}
+	wep := t.getTestWorkerExecutionParams()
+	wep.ActivityRateLimit = ActivityRateLimitOptions{Key: ActivityRateLimitKeyByHeader("tenant"), Limiter: limiter}
+	r, err := newActivityTaskHandler(&client, wep, registry).Execute(taskqueue, newTask(time.Second))
+	t.NoError(err)
+	t.IsType(&workflowservice.RespondActivityTaskCompletedRequest{}, r)
+	t.Equal([]string{"tenant1"}, limiter.keys)
+
+	// Tasks delayed past their timeout are not executed
+	limiter = &recordingActivityRateLimiter{wait: time.Minute}
+	wep.ActivityRateLimit = ActivityRateLimitOptions{Key: ActivityRateLimitKeyByType(), Limiter: limiter}
+	r, err = newActivityTaskHandler(&client, wep, registry).Execute(taskqueue, newTask(50*time.Millisecond))
+	t.Equal(context.DeadlineExceeded, err)
+	t.Nil(r)
+	t.Equal([]string{"test"}, limiter.keys)
+}
+
+type blockingActivityRateLimiter struct {
+	waiting chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingActivityRateLimiter) Wait(ctx context.Context, key string) error {
+	close(b.waiting)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.release:
+		return nil
+	}
+}
+
+func (t *TaskHandlersTestSuite) TestActivityExecutionRateLimitedReturnsSlot() {
+	a := &testActivityDeadline{logger: t.logger}
+	registry := t.registry
+	registry.addActivityWithLock(a.ActivityType().Name, a)
+
+	mockCtrl := gomock.NewController(t.T())
+	mockService := workflowservicemock.NewMockWorkflowServiceClient(mockCtrl)
+	client := WorkflowClient{workflowService: mockService}
+	var heartbeats atomic.Int32
+	mockService.EXPECT().RecordActivityTaskHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *workflowservice.RecordActivityTaskHeartbeatRequest, ...grpc.CallOption) (*workflowservice.RecordActivityTaskHeartbeatResponse, error) {
+			heartbeats.Add(1)
+			return &workflowservice.RecordActivityTaskHeartbeatResponse{}, nil
+		}).MinTimes(1)
+
+	fixedSupplier, err := NewFixedSizeSlotSupplier(1)
+	t.NoError(err)
+	bw := &baseWorker{
+		slotSupplier: newTrackingSlotSupplier(fixedSupplier, trackingSlotSupplierOptions{
+			logger:         t.logger,
+			metricsHandler: metrics.NopHandler,
+		}),
+		metricsHandler: metrics.NopHandler,
+	}
+	permit := bw.slotSupplier.TryReserveSlot(&bw.options.slotReservationData)
+	t.NotNil(permit)
+	bw.slotSupplier.MarkSlotUsed(permit)
+	slot := &taskSlot{bw: bw, permit: permit}
+
+	now := time.Now()
+	task := &workflowservice.PollActivityTaskQueueResponse{
+		Attempt:                1,
+		TaskToken:              []byte("token"),
+		WorkflowExecution:      &commonpb.WorkflowExecution{WorkflowId: "wID", RunId: "rID"},
+		ActivityType:           &commonpb.ActivityType{Name: "test"},
+		ActivityId:             uuid.NewString(),
+		ScheduledTime:          timestamppb.New(now),
+		ScheduleToCloseTimeout: durationpb.New(time.Minute),
+		StartedTime:            timestamppb.New(now),
+		StartToCloseTimeout:    durationpb.New(time.Minute),
+		HeartbeatTimeout:       durationpb.New(20 * time.Millisecond),
+		WorkflowType:           &commonpb.WorkflowType{Name: "wType"},
+		WorkflowNamespace:      "namespace",
+	}
+	limiter := &blockingActivityRateLimiter{waiting: make(chan struct{}), release: make(chan struct{})}
+	wep := t.getTestWorkerExecutionParams()
+	wep.ActivityRateLimit = ActivityRateLimitOptions{Key: ActivityRateLimitKeyByType(), Limiter: limiter}
+	handler := newActivityTaskHandler(&client, wep, registry).(*activityTaskHandlerImpl)
+
+	type result struct {
+		request interface{}
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		r, err := handler.execute(taskqueue, task, slot)
+		done <- result{r, err}
+	}()
+
+	// The throttled activity gives its slot back and heartbeats while it waits
+	<-limiter.waiting
+	t.Eventually(func() bool {
+		return bw.slotSupplier.issuedSlotCount() == 0 && heartbeats.Load() > 0
+	}, time.Second, time.Millisecond)
+	t.Equal(int64(1), bw.waitingTasks.Load())
+	t.Equal(1, bw.inFlightTaskCount())
+
+	// Then executes once it reacquired a slot
+	close(limiter.release)
+	r := <-done
+	t.NoError(r.err)
+	t.IsType(&workflowservice.RespondActivityTaskCompletedRequest{}, r.request)
+	t.NotNil(slot.permit)
+	t.Equal(1, bw.slotSupplier.issuedSlotCount())
+	t.Equal(int64(0), bw.waitingTasks.Load())
+}
+
+func heartbeatingActivity(ctx context.Context) error {
+	RecordActivityHeartbeat(ctx, 1)
+	RecordActivityHeartbeat(ctx, 2)
+	return nil
+}
+
+func (t *TaskHandlersTestSuite) TestActivityExecutionMetrics() {
+	registry := t.registry
+	registry.RegisterActivityWithOptions(heartbeatingActivity, RegisterActivityOptions{Name: "heartbeatingActivity"})
+
+	mockCtrl := gomock.NewController(t.T())
+	mockService := workflowservicemock.NewMockWorkflowServiceClient(mockCtrl)
+	mockService.EXPECT().RecordActivityTaskHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&workflowservice.RecordActivityTaskHeartbeatResponse{}, nil).AnyTimes()
+	client := WorkflowClient{workflowService: mockService}
+	metricsHandler := metrics.NewCapturingHandler()
+	wep := t.getTestWorkerExecutionParams()
+	wep.MetricsHandler = metricsHandler
+
+	now := time.Now()
+	r, err := newActivityTaskHandler(&client, wep, registry).Execute(taskqueue, &workflowservice.PollActivityTaskQueueResponse{
+		Attempt:                5,
+		TaskToken:              []byte("token"),
+		WorkflowExecution:      &commonpb.WorkflowExecution{WorkflowId: "wID", RunId: "rID"},
+		ActivityType:           &commonpb.ActivityType{Name: "heartbeatingActivity"},
+		ActivityId:             uuid.NewString(),
+		ScheduledTime:          timestamppb.New(now),
+		ScheduleToCloseTimeout: durationpb.New(time.Minute),
+		StartedTime:            timestamppb.New(now),
+		StartToCloseTimeout:    durationpb.New(time.Minute),
+		WorkflowType:           &commonpb.WorkflowType{Name: "wType"},
+		WorkflowNamespace:      "namespace",
+	})
+	t.NoError(err)
+	t.IsType(&workflowservice.RespondActivityTaskCompletedRequest{}, r)
+
+	counters := map[string]*metrics.CapturedCounter{}
+	for _, counter := range metricsHandler.Counters() {
+		counters[counter.Name] = counter
+	}
+	t.Equal(int64(1), counters[metrics.ActivityExecutionAttemptCounter].Value())
+	t.Equal("4-10", counters[metrics.ActivityExecutionAttemptCounter].Tags[metrics.AttemptTagName])
+	t.Equal("heartbeatingActivity", counters[metrics.ActivityExecutionAttemptCounter].Tags[metrics.ActivityTypeNameTagName])
+	t.Equal(int64(2), counters[metrics.ActivityHeartbeatCounter].Value())
+
+	timers := map[string]*metrics.CapturedTimer{}
+	for _, timer := range metricsHandler.Timers() {
+		timers[timer.Name] = timer
+	}
+	t.Contains(timers, metrics.ActivityLastHeartbeatAge)
+	t.Contains(timers, metrics.ActivityTimeoutBudgetRemaining)
+	t.Equal(int64(1), counters[metrics.ActivityTimeoutBudgetUtilization].Value())
+	t.Equal("0-25", counters[metrics.ActivityTimeoutBudgetUtilization].Tags[metrics.UtilizationTagName])
+}
@@ -1165,15 +1165,19 @@ func (wtp *workflowTaskPoller) getNextPollRequest() (request *workflowservice.Po
 
 // Poll the workflow task queue and update the num_poller metric
 func (wtp *workflowTaskPoller) pollWorkflowTaskQueue(ctx context.Context, request *workflowservice.PollWorkflowTaskQueueRequest) (*workflowservice.PollWorkflowTaskQueueResponse, error) {
+	pollerType := metrics.PollerTypeWorkflowTask
 	if request.TaskQueue.GetKind() == enumspb.TASK_QUEUE_KIND_NORMAL {
 		wtp.numNormalPollerMetric.increment()
 		defer wtp.numNormalPollerMetric.decrement()
 	} else {
+		pollerType = metrics.PollerTypeWorkflowStickyTask
 		wtp.numStickyPollerMetric.increment()
 		defer wtp.numStickyPollerMetric.decrement()
 	}
 
-	return wtp.service.PollWorkflowTaskQueue(ctx, request)
+	response, err := wtp.service.PollWorkflowTaskQueue(ctx, request)
+	wtp.pollTimeTracker.recordPollCompletion(pollerType, err)
+	return response, err
 }
 
 // Poll for a single workflow task from the service
@@ -1391,7 +1395,9 @@ func (atp *activityTaskPoller) pollActivityTaskQueue(ctx context.Context, reques
 	atp.numPollerMetric.increment()
 	defer atp.numPollerMetric.decrement()
 
-	return atp.service.PollActivityTaskQueue(ctx, request)
+	response, err := atp.service.PollActivityTaskQueue(ctx, request)
+	atp.pollTimeTracker.recordPollCompletion(metrics.PollerTypeActivityTask, err)
+	return response, err
 }
 
 // Poll for a single activity task from the service
@@ -1475,7 +1481,13 @@ func (atp *activityTaskPoller) ProcessTask(task interface{}) error {
 	}
 
 	// Process the activity task.
-	request, err := atp.taskHandler.Execute(atp.taskQueueName, activityTask.task)
+	var request interface{}
+	var err error
+	if taskHandler, ok := atp.taskHandler.(*activityTaskHandlerImpl); ok {
+		request, err = taskHandler.execute(atp.taskQueueName, activityTask.task, activityTask.slot)
+	} else {
+		request, err = atp.taskHandler.Execute(atp.taskQueueName, activityTask.task)
+	}
 
 	// err is returned in case of internal failure, such as unable to propagate context or context timeout.
 	if err != nil {
@@ -1775,6 +1787,10 @@ func (at *activityTask) scaleDecision() (pollerScaleDecision, bool) {
 	}, true
 }
 
+func (at *activityTask) setSlot(slot *taskSlot) {
+	at.slot = slot
+}
+
 func (*localActivityTask) isEmpty() bool {
 	return false
 }
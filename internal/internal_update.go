@@ -76,6 +76,13 @@ type (
 		Send(*protocolpb.Message, ...msgSendOpt)
 	}
 
+	// updateConverterSetter is implemented by UpdateCallbacks that encode the
+	// update outcome themselves, so they can use the update handler's
+	// converters.
+	updateConverterSetter interface {
+		setConverters(converter.DataConverter, converter.FailureConverter)
+	}
+
 	// updateProtocol wraps an updateEnv and some protocol metadata to
 	// implement the UpdateCallbacks abstraction. It handles callbacks by
 	// sending protocol messages.
@@ -215,6 +222,13 @@ func (up *updateProtocol) Complete(success interface{}, outcomeErr error) {
 	up.state = updateStateCompleted
 }
 
+// setConverters sets the converters used for the update outcome to those of
+// the update handler.
+func (up *updateProtocol) setConverters(dc converter.DataConverter, fc converter.FailureConverter) {
+	up.dataConverter = dc
+	up.failureConverter = fc
+}
+
 func (up *updateProtocol) checkCompletedEvent(e *historypb.HistoryEvent) bool {
 	attrs := e.GetWorkflowExecutionUpdateCompletedEventAttributes()
 	if attrs == nil {
@@ -279,9 +293,8 @@ func defaultUpdateHandler(
 			return
 		}
 
-		if up, ok := callbacks.(*updateProtocol); ok {
-			up.dataConverter = handler.dataConverter
-			up.failureConverter = handler.failureConverter
+		if cs, ok := callbacks.(updateConverterSetter); ok {
+			cs.setConverters(handler.dataConverter, handler.failureConverter)
 		}
 
 		args, err := decodeArgsToRawValues(
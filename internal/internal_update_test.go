@@ -13,6 +13,7 @@ import (
 	updatepb "go.temporal.io/api/update/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/internal/common/metrics"
 	"go.temporal.io/sdk/internal/protocol"
 )
 
@@ -600,3 +601,57 @@ func TestAcceptedEventPredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestMeteredUpdateCallbacks(t *testing.T) {
+	capturing := metrics.NewCapturingHandler()
+	isReplay := true
+	var calls []string
+	workflowTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newCallbacks := func() *meteredUpdateCallbacks {
+		return &meteredUpdateCallbacks{
+			UpdateCallbacks: &testUpdateCallbacks{
+				AcceptImpl:   func() { calls = append(calls, "accept") },
+				RejectImpl:   func(error) { calls = append(calls, "reject") },
+				CompleteImpl: func(interface{}, error) { calls = append(calls, "complete") },
+			},
+			metricsHandler: metrics.NewReplayAwareHandler(&isReplay, capturing),
+			now:            func() time.Time { return workflowTime },
+		}
+	}
+	counts := func() map[string]int64 {
+		counts := map[string]int64{}
+		for _, counter := range capturing.Counters() {
+			if counter.Value() != 0 {
+				counts[counter.Name] += counter.Value()
+			}
+		}
+		return counts
+	}
+
+	// An update accepted during replay and completed afterwards is only counted
+	// once completed, with the latency measured between the workflow times it
+	// was accepted and completed at
+	callbacks := newCallbacks()
+	callbacks.Accept()
+	isReplay = false
+	workflowTime = workflowTime.Add(time.Minute)
+	callbacks.Complete(nil, nil)
+	require.Equal(t, map[string]int64{metrics.WorkflowUpdateCompletedCounter: 1}, counts())
+	timers := capturing.Timers()
+	require.Len(t, timers, 1)
+	require.Equal(t, metrics.WorkflowUpdateExecutionLatency, timers[0].Name)
+	require.Equal(t, int64(1), timers[0].Count())
+	require.Equal(t, time.Minute, timers[0].Value())
+
+	callbacks = newCallbacks()
+	callbacks.Accept()
+	callbacks.Complete(nil, errors.New("update failed"))
+	newCallbacks().Reject(errors.New("invalid update"))
+	require.Equal(t, map[string]int64{
+		metrics.WorkflowUpdateAcceptedCounter:  1,
+		metrics.WorkflowUpdateRejectedCounter:  1,
+		metrics.WorkflowUpdateCompletedCounter: 2,
+	}, counts())
+	require.Equal(t, int64(2), timers[0].Count())
+	require.Equal(t, []string{"accept", "complete", "accept", "complete", "reject"}, calls)
+}
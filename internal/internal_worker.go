@@ -66,6 +66,8 @@ const (
 
 	defaultMaxConcurrentSessionExecutionSize = 1000 // Large concurrent session execution size (1k)
 
+	defaultDrainProgressInterval = time.Second // How often Drain reports progress while waiting on in-flight tasks.
+
 	defaultDeadlockDetectionTimeout = time.Second // By default kill workflow tasks that are running more than 1 sec.
 	// Unlimited deadlock detection timeout is used when we want to allow workflow tasks to run indefinitely, such
 	// as during debugging.
@@ -129,6 +131,9 @@ type (
 		// Defines rate limiting on number of activity tasks that can be executed per second per worker.
 		WorkerActivitiesPerSecond float64
 
+		// Defines rate limiting of activity executions by a key extracted from each task.
+		ActivityRateLimit ActivityRateLimitOptions
+
 		// Defines rate limiting on number of local activities that can be executed per second per worker.
 		WorkerLocalActivitiesPerSecond float64
 
@@ -208,6 +213,9 @@ type (
 		// NexusTaskPollerBehavior defines the behavior of the nexus task poller.
 		NexusTaskPollerBehavior PollerBehavior
 
+		// NexusMiddleware is called for each Nexus request before the operation handler.
+		NexusMiddleware []NexusMiddleware
+
 		// Pointer to the shared worker cache
 		cache *WorkerCache
 
@@ -217,6 +225,9 @@ type (
 
 		pollTimeTracker *pollTimeTracker
 
+		// Number of workflow coroutines of the worker that are deadlocked.
+		deadlockedCoroutines *atomic.Int64
+
 		workerInstanceKey string
 
 		workerPollCompleteOnShutdown *atomic.Bool
@@ -1176,6 +1187,7 @@ type AggregatedWorker struct {
 	// Stores a boolean indicating whether the worker has already been started.
 	started      atomic.Bool
 	shuttingDown atomic.Bool
+	draining     atomic.Bool
 	stopC        chan struct{}
 	fatalErr     error
 	fatalErrLock sync.Mutex
@@ -1188,6 +1200,7 @@ type AggregatedWorker struct {
 	heartbeatMetrics             *heartbeatMetricsHandler
 	heartbeatCallback            func() *workerpb.WorkerHeartbeat
 	workerPollCompleteOnShutdown *atomic.Bool
+	onDrainProgress              func(DrainProgress)
 }
 
 // RegisterWorkflow registers workflow implementation with the AggregatedWorker
@@ -1519,9 +1532,91 @@ func (aw *AggregatedWorker) Stop() {
 
 	aw.unregisterHeartbeatWorker()
 
+	if aw.draining.Load() {
+		aw.executionParams.MetricsHandler.Gauge(metrics.WorkerDraining).Update(0)
+	}
+
 	aw.logger.Info("Stopped Worker")
 }
 
+// Drain stops the worker from polling for new activity and Nexus tasks while it
+// keeps processing workflow tasks, then blocks until all in-flight activity and
+// Nexus tasks, including those from polls that were outstanding, have
+// completed. It returns ctx.Err() if ctx is done first, or ErrWorkerShutdown if
+// the worker is stopped while draining. Draining cannot be undone; call Stop
+// once Drain returns.
+func (aw *AggregatedWorker) Drain(ctx context.Context) error {
+	if !aw.started.Load() {
+		return errors.New("cannot drain a worker that has not been started")
+	}
+	select {
+	case <-aw.stopC:
+		return ErrWorkerShutdown
+	default:
+	}
+
+	if aw.draining.CompareAndSwap(false, true) {
+		aw.logger.Info("Draining Worker")
+		aw.executionParams.MetricsHandler.Gauge(metrics.WorkerDraining).Update(1)
+	}
+	activityWorkers, nexusWorkers := aw.drainableWorkers()
+	for _, bw := range activityWorkers {
+		bw.drain()
+	}
+	for _, bw := range nexusWorkers {
+		bw.drain()
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(defaultDrainProgressInterval)
+	defer ticker.Stop()
+	for {
+		progress := DrainProgress{Elapsed: time.Since(start)}
+		for _, bw := range activityWorkers {
+			progress.InFlightActivities += bw.inFlightTaskCount()
+		}
+		for _, bw := range nexusWorkers {
+			progress.InFlightNexusTasks += bw.inFlightTaskCount()
+		}
+		if aw.onDrainProgress != nil {
+			aw.onDrainProgress(progress)
+		}
+		if progress.InFlightActivities == 0 && progress.InFlightNexusTasks == 0 {
+			aw.logger.Info("Drained Worker", "Elapsed", progress.Elapsed)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			aw.logger.Warn("Worker drain did not complete.",
+				"InFlightActivities", progress.InFlightActivities,
+				"InFlightNexusTasks", progress.InFlightNexusTasks,
+				tagError, ctx.Err())
+			return ctx.Err()
+		case <-aw.stopC:
+			return ErrWorkerShutdown
+		case <-ticker.C:
+		}
+	}
+}
+
+// drainableWorkers returns the base workers that stop polling when draining,
+// split into those executing activities and those executing Nexus tasks.
+func (aw *AggregatedWorker) drainableWorkers() (activityWorkers []*baseWorker, nexusWorkers []*baseWorker) {
+	if !util.IsInterfaceNil(aw.activityWorker) {
+		activityWorkers = append(activityWorkers, aw.activityWorker.worker)
+	}
+	if !util.IsInterfaceNil(aw.sessionWorker) {
+		activityWorkers = append(activityWorkers,
+			aw.sessionWorker.creationWorker.worker,
+			aw.sessionWorker.activityWorker.worker)
+	}
+	if !util.IsInterfaceNil(aw.nexusWorker) {
+		nexusWorkers = append(nexusWorkers, aw.nexusWorker.worker)
+	}
+	return activityWorkers, nexusWorkers
+}
+
 func (aw *AggregatedWorker) registerHeartbeatWorker() error {
 	if aw.client.heartbeatManager == nil {
 		return nil
@@ -2214,6 +2309,7 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		TaskQueue:                        taskQueue,
 		Tuner:                            options.Tuner,
 		WorkerActivitiesPerSecond:        options.WorkerActivitiesPerSecond,
+		ActivityRateLimit:                options.ActivityRateLimit,
 		WorkerLocalActivitiesPerSecond:   options.WorkerLocalActivitiesPerSecond,
 		Identity:                         client.identity,
 		WorkerBuildID:                    options.BuildID,
@@ -2235,6 +2331,7 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		DeadlockDetectionTimeout:         options.DeadlockDetectionTimeout,
 		DefaultHeartbeatThrottleInterval: options.DefaultHeartbeatThrottleInterval,
 		MaxHeartbeatThrottleInterval:     options.MaxHeartbeatThrottleInterval,
+		NexusMiddleware:                  options.NexusMiddleware,
 		cache:                            cache,
 		eagerActivityExecutor: newEagerActivityExecutor(eagerActivityExecutorOptions{
 			disabled:      options.DisableEagerActivities,
@@ -2243,6 +2340,7 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		}),
 		capabilities:                 &capabilities,
 		pollTimeTracker:              &pollTimeTracker{},
+		deadlockedCoroutines:         &atomic.Int64{},
 		workerInstanceKey:            workerInstanceKey,
 		workerPollCompleteOnShutdown: workerPollCompleteOnShutdown,
 		serverSupportsAutoscaling:    &atomic.Bool{},
@@ -2286,6 +2384,7 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 	}
 
 	ensureRequiredParams(&workerParams)
+	workerParams.Logger = newSamplingLogger(workerParams.Logger, options.LogSampling, workerParams.MetricsHandler)
 	workerParams.Logger = log.With(workerParams.Logger,
 		tagNamespace, client.namespace,
 		tagTaskQueue, taskQueue,
@@ -2403,7 +2502,7 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 			previousHeartbeatTime = heartbeatTime
 
 			status := enumspb.WORKER_STATUS_RUNNING
-			if aw.shuttingDown.Load() {
+			if aw.shuttingDown.Load() || aw.draining.Load() {
 				status = enumspb.WORKER_STATUS_SHUTTING_DOWN
 			}
 
@@ -2450,6 +2549,7 @@ func NewAggregatedWorker(client *WorkflowClient, taskQueue string, options Worke
 		heartbeatMetrics:             heartbeatMetrics,
 		heartbeatCallback:            heartbeatCallback,
 		workerPollCompleteOnShutdown: workerPollCompleteOnShutdown,
+		onDrainProgress:              options.OnDrainProgress,
 	}
 
 	// Set memoized start as a once-value that invokes plugins first
@@ -216,6 +216,40 @@ type (
 		lastPollTaskErrLock    sync.Mutex
 
 		noRepoll atomic.Bool
+		// Set once the worker has been asked to drain. A draining worker no
+		// longer polls or accepts eager tasks but keeps processing the tasks it
+		// already has.
+		draining atomic.Bool
+
+		// Unix nanos of when the worker was started, zero when not running. Used
+		// for worker health reporting.
+		startedAt atomic.Int64
+		// Number of tasks that gave their slot back while they wait to run, see
+		// taskSlot.
+		waitingTasks atomic.Int64
+	}
+
+	// taskSlot is the slot a task is processed in. A task that has to wait
+	// before it can run, such as a rate limited activity, may give the slot
+	// back for the wait and reacquire one afterwards.
+	taskSlot struct {
+		bw     *baseWorker
+		permit *SlotPermit
+	}
+
+	// slotHoldingTask is implemented by tasks that may give their slot back
+	// while they are processed.
+	slotHoldingTask interface {
+		setSlot(slot *taskSlot)
+	}
+
+	// baseWorkerHealth is a point-in-time view of a base worker used to compute
+	// worker liveness and readiness.
+	baseWorkerHealth struct {
+		startedAt     time.Time
+		draining      bool
+		lastPollError string
+		slotsUsed     int
 	}
 
 	eagerOrPolledTask interface {
@@ -410,6 +444,7 @@ func (bw *baseWorker) Start() {
 	go bw.runEagerTaskDispatcher()
 
 	bw.isWorkerStarted = true
+	bw.startedAt.Store(time.Now().UnixNano())
 	traceLog(func() {
 		bw.logger.Info("Started Worker",
 			"MaxTaskPerSecond", bw.options.maxTaskPerSecond,
@@ -486,6 +521,11 @@ func (bw *baseWorker) runPoller(taskWorker scalableTaskPoller) {
 					}
 					return false
 				}
+				// The worker may have started draining while we were waiting on a slot
+				if bw.noRepoll.Load() {
+					bw.releaseSlot(permit, SlotReleaseReasonUnused)
+					return true
+				}
 				if bw.sessionTokenBucket != nil {
 					bw.sessionTokenBucket.waitForAvailableToken()
 				}
@@ -505,7 +545,7 @@ func (bw *baseWorker) runPoller(taskWorker scalableTaskPoller) {
 }
 
 func (bw *baseWorker) tryReserveSlot() *SlotPermit {
-	if bw.isStop() {
+	if bw.isStop() || bw.draining.Load() {
 		return nil
 	}
 	return bw.slotSupplier.TryReserveSlot(&bw.options.slotReservationData)
@@ -535,9 +575,15 @@ func (bw *baseWorker) processTaskAsync(eagerOrPolled eagerOrPolledTask) {
 		if !task.isEmpty() {
 			bw.slotSupplier.MarkSlotUsed(permit)
 		}
+		slot := &taskSlot{bw: bw, permit: permit}
+		if t, ok := task.(slotHoldingTask); ok {
+			t.setSlot(slot)
+		}
 
 		defer func() {
-			bw.releaseSlot(permit, SlotReleaseReasonTaskProcessed)
+			if slot.permit != nil {
+				bw.releaseSlot(slot.permit, SlotReleaseReasonTaskProcessed)
+			}
 
 			if p := recover(); p != nil {
 				topLine := "base worker [panic]:"
@@ -647,6 +693,21 @@ func (bw *baseWorker) pollTask(taskWorker scalableTaskPoller, slotPermit *SlotPe
 	}
 }
 
+// health returns a snapshot of the state used for worker health reporting.
+func (bw *baseWorker) health() baseWorkerHealth {
+	h := baseWorkerHealth{
+		draining:  bw.draining.Load(),
+		slotsUsed: bw.slotSupplier.usedSlotCount(),
+	}
+	if nanos := bw.startedAt.Load(); nanos != 0 {
+		h.startedAt = time.Unix(0, nanos)
+	}
+	bw.lastPollTaskErrLock.Lock()
+	h.lastPollError = bw.lastPollTaskErrMessage
+	bw.lastPollTaskErrLock.Unlock()
+	return h
+}
+
 func (bw *baseWorker) logPollTaskError(err error) {
 	// We do not want to log any errors after we were explicitly stopped
 	select {
@@ -695,6 +756,43 @@ func isNonRetriableError(err error) bool {
 	return false
 }
 
+// drain stops the worker from polling for or accepting new tasks. Tasks that are
+// already being processed are left to run to completion.
+func (bw *baseWorker) drain() {
+	bw.draining.Store(true)
+	bw.noRepoll.Store(true)
+}
+
+// inFlightTaskCount returns the number of reserved slots and publishes it as the
+// drain in-flight gauge. Besides the tasks being processed, this counts the polls
+// that are still open and the tasks they delivered that haven't been processed yet,
+// since either may still end up running a task, and the tasks waiting without a slot.
+func (bw *baseWorker) inFlightTaskCount() int {
+	count := bw.slotSupplier.issuedSlotCount() + int(bw.waitingTasks.Load())
+	bw.metricsHandler.Gauge(metrics.WorkerDrainInFlightTasks).Update(float64(count))
+	return count
+}
+
+// release gives the slot back while the task waits. The task must reacquire a
+// slot before it runs.
+func (s *taskSlot) release() {
+	s.bw.waitingTasks.Add(1)
+	s.bw.releaseSlot(s.permit, SlotReleaseReasonUnused)
+	s.permit = nil
+}
+
+// reacquire blocks until a slot is reserved for the task after release.
+func (s *taskSlot) reacquire(ctx context.Context) error {
+	defer s.bw.waitingTasks.Add(-1)
+	permit, err := s.bw.slotSupplier.ReserveSlot(ctx, &s.bw.options.slotReservationData)
+	if err != nil {
+		return err
+	}
+	s.bw.slotSupplier.MarkSlotUsed(permit)
+	s.permit = permit
+	return nil
+}
+
 // Stop is a blocking call and cleans up all the resources associated with worker.
 func (bw *baseWorker) Stop() {
 	if !bw.isWorkerStarted {
@@ -714,7 +812,12 @@ func (bw *baseWorker) Stop() {
 		bw.options.backgroundContextCancel(ErrWorkerShutdown)
 	}
 
+	if bw.draining.Load() {
+		bw.metricsHandler.Gauge(metrics.WorkerDrainInFlightTasks).Update(0)
+	}
+
 	bw.isWorkerStarted = false
+	bw.startedAt.Store(0)
 }
 
 func newPollScalerReportHandle(options pollScalerReportHandleOptions) *pollScalerReportHandle {
@@ -397,3 +397,108 @@ func (s *PollScalerReportHandleSuite) TestErrorScaleDownWithCapability() {
 	ps.handleError(serviceerror.NewInternal("test error"))
 	assert.Equal(s.T(), 3, targetSuggestion)
 }
+
+type countingTaskPoller struct {
+	polls atomic.Int32
+}
+
+func (p *countingTaskPoller) PollTask() (taskForWorker, error) {
+	p.polls.Add(1)
+	return newTestTask(0), nil
+}
+
+type blockingTaskProcessor struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingTaskProcessor) ProcessTask(any) error {
+	p.started <- struct{}{}
+	<-p.release
+	return nil
+}
+
+func TestBaseWorkerDrain(t *testing.T) {
+	taskPoller := &countingTaskPoller{}
+	processor := &blockingTaskProcessor{started: make(chan struct{}, 1), release: make(chan struct{})}
+	poller := newScalableTaskPoller(taskPoller, ilog.NewNopLogger(), &pollerBehaviorSimpleMaximum{maximumNumberOfPollers: 1}, nil)
+	poller.taskPollerType = "test"
+	slotSupplier, err := NewFixedSizeSlotSupplier(1)
+	require.NoError(t, err)
+
+	bw := newBaseWorker(baseWorkerOptions{
+		slotSupplier:     slotSupplier,
+		maxTaskPerSecond: 1000,
+		taskPollers:      []scalableTaskPoller{poller},
+		taskProcessor:    processor,
+		workerType:       "DrainTest",
+		logger:           ilog.NewNopLogger(),
+		stopTimeout:      time.Second,
+		metricsHandler:   metrics.NopHandler,
+	})
+	bw.Start()
+	defer bw.Stop()
+
+	// Wait for the only slot to be used by an in-flight task, then drain
+	<-processor.started
+	bw.drain()
+	require.Equal(t, 1, bw.inFlightTaskCount())
+	require.Nil(t, bw.tryReserveSlot(), "draining worker should not accept eager tasks")
+
+	// Once the in-flight task completes, the poller must not poll again
+	polls := taskPoller.polls.Load()
+	close(processor.release)
+	require.Eventually(t, func() bool { return bw.inFlightTaskCount() == 0 }, time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool { return taskPoller.polls.Load() > polls }, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+type blockingTaskPoller struct {
+	polling chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingTaskPoller) PollTask() (taskForWorker, error) {
+	p.polling <- struct{}{}
+	<-p.release
+	return newTestTask(0), nil
+}
+
+type recordingTaskProcessor struct {
+	processed atomic.Int32
+}
+
+func (p *recordingTaskProcessor) ProcessTask(any) error {
+	p.processed.Add(1)
+	return nil
+}
+
+func TestBaseWorkerDrainWaitsForOpenPolls(t *testing.T) {
+	taskPoller := &blockingTaskPoller{polling: make(chan struct{}, 1), release: make(chan struct{})}
+	processor := &recordingTaskProcessor{}
+	poller := newScalableTaskPoller(taskPoller, ilog.NewNopLogger(), &pollerBehaviorSimpleMaximum{maximumNumberOfPollers: 1}, nil)
+	poller.taskPollerType = "test"
+	slotSupplier, err := NewFixedSizeSlotSupplier(2)
+	require.NoError(t, err)
+
+	bw := newBaseWorker(baseWorkerOptions{
+		slotSupplier:     slotSupplier,
+		maxTaskPerSecond: 1000,
+		taskPollers:      []scalableTaskPoller{poller},
+		taskProcessor:    processor,
+		workerType:       "DrainTest",
+		logger:           ilog.NewNopLogger(),
+		stopTimeout:      time.Second,
+		metricsHandler:   metrics.NopHandler,
+	})
+	bw.Start()
+	defer bw.Stop()
+
+	// A poll that is outstanding when draining starts may still deliver a task,
+	// so it must be counted as in flight until that task is processed
+	<-taskPoller.polling
+	bw.drain()
+	require.Equal(t, 1, bw.inFlightTaskCount())
+	close(taskPoller.release)
+	require.Eventually(t, func() bool { return bw.inFlightTaskCount() == 0 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(1), processor.processed.Load())
+}
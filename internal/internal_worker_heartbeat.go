@@ -196,14 +196,42 @@ func (hw *sharedNamespaceWorker) stop() {
 // pollTimeTracker tracks the last successful poll time for each poller type.
 type pollTimeTracker struct {
 	times sync.Map // pollerType (string) -> time.Time (stored as int64 nanos)
+	// Same as times, but also including polls that returned no task, and polls
+	// that failed, respectively. Used to report worker health.
+	responseTimes   sync.Map
+	completionTimes sync.Map
 }
 
 func (p *pollTimeTracker) recordPollSuccess(pollerType string) {
 	p.times.Store(pollerType, time.Now().UnixNano())
 }
 
+// recordPollCompletion records that a poll returned, with or without a task.
+func (p *pollTimeTracker) recordPollCompletion(pollerType string, err error) {
+	now := time.Now().UnixNano()
+	p.completionTimes.Store(pollerType, now)
+	if err == nil {
+		p.responseTimes.Store(pollerType, now)
+	}
+}
+
 func (p *pollTimeTracker) getLastPollTime(pollerType string) time.Time {
-	if v, ok := p.times.Load(pollerType); ok {
+	return loadPollTime(&p.times, pollerType)
+}
+
+// getLastPollResponseTime returns when a poll last returned without error.
+func (p *pollTimeTracker) getLastPollResponseTime(pollerType string) time.Time {
+	return loadPollTime(&p.responseTimes, pollerType)
+}
+
+// getLastPollCompletionTime returns when a poll last returned, even with an
+// error.
+func (p *pollTimeTracker) getLastPollCompletionTime(pollerType string) time.Time {
+	return loadPollTime(&p.completionTimes, pollerType)
+}
+
+func loadPollTime(times *sync.Map, pollerType string) time.Time {
+	if v, ok := times.Load(pollerType); ok {
 		return time.Unix(0, v.(int64))
 	}
 	return time.Time{}
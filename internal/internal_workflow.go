@@ -667,11 +667,15 @@ func (d *syncWorkflowDefinition) Close() {
 // This way rootCtx can be used to pass values to the coroutine code.
 func newDispatcher(rootCtx Context, interceptor *workflowEnvironmentInterceptor, root func(ctx Context), allBlockedCallback func() bool) (*dispatcherImpl, Context) {
 	env := getWorkflowEnvironment(rootCtx)
+	var deadlockedCoroutines *atomic.Int64
+	if env, ok := env.(*workflowEnvironmentImpl); ok {
+		deadlockedCoroutines = env.deadlockedCoroutines
+	}
 
 	result := &dispatcherImpl{
 		interceptor:        interceptor.outboundInterceptor,
 		logger:             env.GetLogger(),
-		deadlockDetector:   newDeadlockDetector(),
+		deadlockDetector:   newDeadlockDetector(deadlockedCoroutines),
 		allBlockedCallback: allBlockedCallback,
 	}
 	interceptor.dispatcher = result
@@ -1167,6 +1171,10 @@ func (s *coroutineState) call(timeout time.Duration) {
 			"workflow goroutine %q didn't yield for over a second", s.name)
 		s.closed.Store(true)
 		s.panicError = newWorkflowPanicError(msg, st)
+		// The coroutine is still running. Nothing else reads aboutToBlock once
+		// the coroutine is closed, so use it to track when the coroutine
+		// eventually yields or exits.
+		s.dispatcher.deadlockDetector.trackDeadlocked(s.aboutToBlock)
 	}
 }
 
@@ -31,6 +31,7 @@ import (
 	"go.temporal.io/api/serviceerror"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
 	updatepb "go.temporal.io/api/update/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/sdk/converter"
@@ -1157,18 +1158,22 @@ func (w *WorkflowExecutionDescription) GetStaticDetails() (string, error) {
 //
 // NOTE: Experimental
 func (w *WorkflowExecutionDescription) GetMemoValue(key string, valuePtr interface{}) error {
-	if w.Memo == nil {
+	return decodeMemoValue(w.Memo, key, w.inboundPayloadVisitor, w.dc, valuePtr)
+}
+
+func decodeMemoValue(memo *commonpb.Memo, key string, visitor PayloadVisitor, dc converter.DataConverter, valuePtr interface{}) error {
+	if memo == nil {
 		return ErrNoData
 	}
-	payload, ok := w.Memo.Fields[key]
+	payload, ok := memo.Fields[key]
 	if !ok {
 		return ErrNoData
 	}
 	var err error
-	if payload, err = visitPayload(context.Background(), w.inboundPayloadVisitor, payload); err != nil {
+	if payload, err = visitPayload(context.Background(), visitor, payload); err != nil {
 		return err
 	}
-	return w.dc.FromPayload(payload, valuePtr)
+	return dc.FromPayload(payload, valuePtr)
 }
 
 // QueryWorkflowWithOptions queries a given workflow execution and returns the query result synchronously.
@@ -1524,15 +1529,24 @@ func (wc *WorkflowClient) CheckHealth(ctx context.Context, request *CheckHealthR
 	}
 
 	// Ignore request/response for now, they are empty
-	resp, err := healthpb.NewHealthClient(wc.conn).Check(ctx, &healthpb.HealthCheckRequest{
-		Service: "temporal.api.workflowservice.v1.WorkflowService",
+	if err := checkHealth(ctx, wc.conn); err != nil {
+		return nil, err
+	}
+	return &CheckHealthResponse{}, nil
+}
+
+// checkHealth checks the workflow service on the given connection using the
+// gRPC health check API.
+func checkHealth(ctx context.Context, conn grpc.ClientConnInterface) error {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: workflowServiceHealthCheckName,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("health check error: %w", err)
+		return fmt.Errorf("health check error: %w", err)
 	} else if resp.Status != healthpb.HealthCheckResponse_SERVING {
-		return nil, fmt.Errorf("health check returned unhealthy status: %v", resp.Status)
+		return fmt.Errorf("health check returned unhealthy status: %v", resp.Status)
 	}
-	return &CheckHealthResponse{}, nil
+	return nil
 }
 
 // WorkflowService implements Client.WorkflowService.
@@ -2446,7 +2460,6 @@ func (w *workflowClientInterceptor) SignalWithStartWorkflow(
 
 	signalWithStartRequest := &workflowservice.SignalWithStartWorkflowExecutionRequest{
 		Namespace:                w.client.namespace,
-		RequestId:                uuid.NewString(),
 		WorkflowId:               in.Options.ID,
 		WorkflowType:             &commonpb.WorkflowType{Name: in.WorkflowType},
 		TaskQueue:                &taskqueuepb.TaskQueue{Name: in.Options.TaskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
@@ -2466,6 +2479,13 @@ func (w *workflowClientInterceptor) SignalWithStartWorkflow(
 		Header:                   header,
 		VersioningOverride:       versioningOverrideToProto(in.Options.VersioningOverride),
 		Priority:                 convertToPBPriority(in.Options.Priority),
+		Links:                    in.Options.links,
+	}
+
+	if in.Options.requestID != "" {
+		signalWithStartRequest.RequestId = in.Options.requestID
+	} else {
+		signalWithStartRequest.RequestId = uuid.NewString()
 	}
 
 	if in.Options.StartDelay != 0 {
@@ -2594,8 +2614,24 @@ func (w *workflowClientInterceptor) DescribeWorkflow(
 	if err != nil {
 		return nil, err
 	}
-	info := resp.GetWorkflowExecutionInfo()
+	m := convertFromPBWorkflowExecutionInfo(w.client.logger, resp.GetWorkflowExecutionInfo())
+	o := &WorkflowExecutionDescription{
+		WorkflowExecutionMetadata: m,
+		dc: converter.WithDataConverterSerializationContext(w.client.dataConverter, converter.WorkflowSerializationContext{
+			Namespace:  w.client.namespace,
+			WorkflowID: in.WorkflowID,
+		}),
+		inboundPayloadVisitor: w.client.inboundPayloadVisitor,
+		staticSummaryPayload:  resp.GetExecutionConfig().GetUserMetadata().GetSummary(),
+		staticDetailsPayload:  resp.GetExecutionConfig().GetUserMetadata().GetDetails(),
+	}
 
+	return &ClientDescribeWorkflowOutput{
+		Response: o,
+	}, nil
+}
+
+func convertFromPBWorkflowExecutionInfo(logger log.Logger, info *workflowpb.WorkflowExecutionInfo) WorkflowExecutionMetadata {
 	var closeTime *time.Time
 	if info.GetCloseTime().IsValid() {
 		t := info.GetCloseTime().AsTime()
@@ -2623,7 +2659,7 @@ func (w *workflowClientInterceptor) DescribeWorkflow(
 		}
 	}
 
-	m := WorkflowExecutionMetadata{
+	return WorkflowExecutionMetadata{
 		WorkflowExecution: WorkflowExecution{
 			ID:    info.GetExecution().GetWorkflowId(),
 			RunID: info.GetExecution().GetRunId(),
@@ -2633,7 +2669,7 @@ func (w *workflowClientInterceptor) DescribeWorkflow(
 		},
 		TaskQueueName:           info.GetTaskQueue(),
 		Memo:                    info.Memo,
-		TypedSearchAttributes:   convertToTypedSearchAttributes(w.client.logger, info.GetSearchAttributes().IndexedFields),
+		TypedSearchAttributes:   convertToTypedSearchAttributes(logger, info.GetSearchAttributes().GetIndexedFields()),
 		Status:                  info.GetStatus(),
 		ParentWorkflowExecution: parentWorkflowExecution,
 		RootWorkflowExecution:   rootWorkflowExecution,
@@ -2642,20 +2678,6 @@ func (w *workflowClientInterceptor) DescribeWorkflow(
 		WorkflowCloseTime:       closeTime,
 		HistoryLength:           int(info.GetHistoryLength()),
 	}
-	o := &WorkflowExecutionDescription{
-		WorkflowExecutionMetadata: m,
-		dc: converter.WithDataConverterSerializationContext(w.client.dataConverter, converter.WorkflowSerializationContext{
-			Namespace:  w.client.namespace,
-			WorkflowID: in.WorkflowID,
-		}),
-		inboundPayloadVisitor: w.client.inboundPayloadVisitor,
-		staticSummaryPayload:  resp.GetExecutionConfig().GetUserMetadata().GetSummary(),
-		staticDetailsPayload:  resp.GetExecutionConfig().GetUserMetadata().GetDetails(),
-	}
-
-	return &ClientDescribeWorkflowOutput{
-		Response: o,
-	}, nil
 }
 
 func (w *workflowClientInterceptor) QueryWorkflow(
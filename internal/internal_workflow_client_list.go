@@ -0,0 +1,244 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	filterpb "go.temporal.io/api/filter/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.temporal.io/sdk/converter"
+)
+
+type (
+	// ClientListWorkflowExecutionsOptions are the options of ListWorkflowExecutions and ListArchivedWorkflowExecutions.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ListWorkflowExecutionsOptions]
+	ClientListWorkflowExecutionsOptions struct {
+		// Query - Filter the workflow executions using a SQL-like query.
+		// Archived workflow executions only support the queries of the visibility archiver of the namespace.
+		//
+		// Optional: defaulted to all the workflow executions
+		Query string
+
+		// TypedQuery - Filter the workflow executions using a query built from typed search attribute keys. Cannot be
+		// set with Query.
+		//
+		// Optional: defaulted to all the workflow executions
+		TypedQuery VisibilityQuery
+
+		// PageSize - How many workflow executions to fetch from the server at a time.
+		//
+		// Optional: defaulted by the server, or to MaxResults if it is lower
+		PageSize int
+
+		// MaxResults - How many workflow executions to return at most.
+		//
+		// Optional: defaulted to all the matching workflow executions
+		MaxResults int
+	}
+
+	// ClientListClosedWorkflowExecutionsOptions are the options of ListClosedWorkflowExecutions. At most one of
+	// WorkflowID, WorkflowType and Status can be set.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ListClosedWorkflowExecutionsOptions]
+	ClientListClosedWorkflowExecutionsOptions struct {
+		// EarliestStartTime - Only list the workflow executions started at or after this time.
+		//
+		// Optional: defaulted to no lower bound
+		EarliestStartTime time.Time
+
+		// LatestStartTime - Only list the workflow executions started at or before this time.
+		//
+		// Optional: defaulted to no upper bound
+		LatestStartTime time.Time
+
+		// WorkflowID - Only list the workflow executions with this workflow ID.
+		//
+		// Optional
+		WorkflowID string
+
+		// WorkflowType - Only list the workflow executions of this workflow type.
+		//
+		// Optional
+		WorkflowType string
+
+		// Status - Only list the workflow executions closed with this status.
+		//
+		// Optional
+		Status enumspb.WorkflowExecutionStatus
+
+		// PageSize - How many workflow executions to fetch from the server at a time.
+		//
+		// Optional: defaulted by the server, or to MaxResults if it is lower
+		PageSize int
+
+		// MaxResults - How many workflow executions to return at most.
+		//
+		// Optional: defaulted to all the matching workflow executions
+		MaxResults int
+	}
+
+	// ClientListWorkflowExecutionsResult contains the result of the ListWorkflowExecutions,
+	// ListArchivedWorkflowExecutions and ListClosedWorkflowExecutions calls. Iterating over Results fetches the pages
+	// from the server as needed and stops after the first error.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ListWorkflowExecutionsResult]
+	ClientListWorkflowExecutionsResult struct {
+		Results iter.Seq2[*ClientWorkflowExecutionInfo, error]
+	}
+
+	// ClientWorkflowExecutionInfo contains information about a workflow execution returned by ListWorkflowExecutions,
+	// ListArchivedWorkflowExecutions and ListClosedWorkflowExecutions.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.WorkflowExecutionInfo]
+	ClientWorkflowExecutionInfo struct {
+		WorkflowExecutionMetadata
+		// Raw PB message this struct was built from.
+		RawExecutionInfo      *workflowpb.WorkflowExecutionInfo
+		dc                    converter.DataConverter
+		inboundPayloadVisitor PayloadVisitor
+	}
+)
+
+// GetMemoValue decodes a memo value by key into valuePtr.
+// Returns ErrNoData if the memo is nil or the key is not present.
+//
+// NOTE: Experimental
+func (w *ClientWorkflowExecutionInfo) GetMemoValue(key string, valuePtr interface{}) error {
+	return decodeMemoValue(w.Memo, key, w.inboundPayloadVisitor, w.dc, valuePtr)
+}
+
+// ListWorkflowExecutions implements Client.
+func (wc *WorkflowClient) ListWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	query, err := visibilityQueryOrString(options.Query, options.TypedQuery)
+	if err != nil {
+		return ClientListWorkflowExecutionsResult{}, err
+	}
+	return wc.listWorkflowExecutions(options.PageSize, options.MaxResults, func(pageSize int32, nextPageToken []byte) ([]*workflowpb.WorkflowExecutionInfo, []byte, error) {
+		resp, err := wc.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			PageSize:      pageSize,
+			NextPageToken: nextPageToken,
+			Query:         query,
+		})
+		return resp.GetExecutions(), resp.GetNextPageToken(), err
+	}), nil
+}
+
+// ListArchivedWorkflowExecutions implements Client.
+func (wc *WorkflowClient) ListArchivedWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	query, err := visibilityQueryOrString(options.Query, options.TypedQuery)
+	if err != nil {
+		return ClientListWorkflowExecutionsResult{}, err
+	}
+	return wc.listWorkflowExecutions(options.PageSize, options.MaxResults, func(pageSize int32, nextPageToken []byte) ([]*workflowpb.WorkflowExecutionInfo, []byte, error) {
+		resp, err := wc.ListArchivedWorkflow(ctx, &workflowservice.ListArchivedWorkflowExecutionsRequest{
+			PageSize:      pageSize,
+			NextPageToken: nextPageToken,
+			Query:         query,
+		})
+		return resp.GetExecutions(), resp.GetNextPageToken(), err
+	}), nil
+}
+
+// ListClosedWorkflowExecutions implements Client.
+func (wc *WorkflowClient) ListClosedWorkflowExecutions(ctx context.Context, options ClientListClosedWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	request := &workflowservice.ListClosedWorkflowExecutionsRequest{}
+	if !options.EarliestStartTime.IsZero() || !options.LatestStartTime.IsZero() {
+		request.StartTimeFilter = &filterpb.StartTimeFilter{}
+		if !options.EarliestStartTime.IsZero() {
+			request.StartTimeFilter.EarliestTime = timestamppb.New(options.EarliestStartTime)
+		}
+		if !options.LatestStartTime.IsZero() {
+			request.StartTimeFilter.LatestTime = timestamppb.New(options.LatestStartTime)
+		}
+	}
+	filters := 0
+	if options.WorkflowID != "" {
+		filters++
+		request.Filters = &workflowservice.ListClosedWorkflowExecutionsRequest_ExecutionFilter{
+			ExecutionFilter: &filterpb.WorkflowExecutionFilter{WorkflowId: options.WorkflowID},
+		}
+	}
+	if options.WorkflowType != "" {
+		filters++
+		request.Filters = &workflowservice.ListClosedWorkflowExecutionsRequest_TypeFilter{
+			TypeFilter: &filterpb.WorkflowTypeFilter{Name: options.WorkflowType},
+		}
+	}
+	if options.Status != enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED {
+		filters++
+		request.Filters = &workflowservice.ListClosedWorkflowExecutionsRequest_StatusFilter{
+			StatusFilter: &filterpb.StatusFilter{Status: options.Status},
+		}
+	}
+	if filters > 1 {
+		return ClientListWorkflowExecutionsResult{}, errors.New("at most one of WorkflowID, WorkflowType and Status can be set")
+	}
+	return wc.listWorkflowExecutions(options.PageSize, options.MaxResults, func(pageSize int32, nextPageToken []byte) ([]*workflowpb.WorkflowExecutionInfo, []byte, error) {
+		request.MaximumPageSize = pageSize
+		request.NextPageToken = nextPageToken
+		resp, err := wc.ListClosedWorkflow(ctx, request)
+		return resp.GetExecutions(), resp.GetNextPageToken(), err
+	}), nil
+}
+
+// listWorkflowExecutions iterates over the workflow executions of the pages returned by getPage, until there is no next
+// page or maxResults are returned.
+func (wc *WorkflowClient) listWorkflowExecutions(
+	pageSize int,
+	maxResults int,
+	getPage func(pageSize int32, nextPageToken []byte) ([]*workflowpb.WorkflowExecutionInfo, []byte, error),
+) ClientListWorkflowExecutionsResult {
+	if maxResults > 0 && (pageSize <= 0 || pageSize > maxResults) {
+		pageSize = maxResults
+	}
+	return ClientListWorkflowExecutionsResult{
+		Results: func(yield func(*ClientWorkflowExecutionInfo, error) bool) {
+			var nextPageToken []byte
+			returned := 0
+			for {
+				executions, token, err := getPage(int32(pageSize), nextPageToken)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				for _, execution := range executions {
+					info := &ClientWorkflowExecutionInfo{
+						WorkflowExecutionMetadata: convertFromPBWorkflowExecutionInfo(wc.logger, execution),
+						RawExecutionInfo:          execution,
+						dc: converter.WithDataConverterSerializationContext(wc.dataConverter, converter.WorkflowSerializationContext{
+							Namespace:  wc.namespace,
+							WorkflowID: execution.GetExecution().GetWorkflowId(),
+						}),
+						inboundPayloadVisitor: wc.inboundPayloadVisitor,
+					}
+					if !yield(info, nil) {
+						return
+					}
+					returned++
+					if maxResults > 0 && returned >= maxResults {
+						return
+					}
+				}
+				if len(token) == 0 {
+					return
+				}
+				nextPageToken = token
+			}
+		},
+	}
+}
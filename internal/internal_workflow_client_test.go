@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	ilog "go.temporal.io/sdk/internal/log"
 
@@ -2130,6 +2131,159 @@ func (s *workflowClientTestSuite) TestListArchivedWorkflow() {
 	s.IsType(&serviceerror.InvalidArgument{}, err)
 }
 
+func (s *workflowClientTestSuite) TestListWorkflowExecutions() {
+	memo, err := getWorkflowMemo(map[string]interface{}{"team": "data"}, s.dataConverter, true)
+	s.NoError(err)
+	searchAttributes, err := serializeTypedSearchAttributes(NewSearchAttributes(NewSearchAttributeKeyKeyword("Env").ValueSet("prod")).GetUntypedValues())
+	s.NoError(err)
+	execution := func(id string) *workflowpb.WorkflowExecutionInfo {
+		return &workflowpb.WorkflowExecutionInfo{
+			Execution:        &commonpb.WorkflowExecution{WorkflowId: id, RunId: id + "-run"},
+			Type:             &commonpb.WorkflowType{Name: workflowType},
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			Memo:             memo,
+			SearchAttributes: searchAttributes,
+		}
+	}
+	var requests []*workflowservice.ListWorkflowExecutionsRequest
+	s.service.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *workflowservice.ListWorkflowExecutionsRequest, _ ...grpc.CallOption) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			requests = append(requests, req)
+			if req.NextPageToken == nil {
+				return &workflowservice.ListWorkflowExecutionsResponse{
+					Executions:    []*workflowpb.WorkflowExecutionInfo{execution("wid1"), execution("wid2")},
+					NextPageToken: []byte("page2"),
+				}, nil
+			}
+			return &workflowservice.ListWorkflowExecutionsResponse{
+				Executions: []*workflowpb.WorkflowExecutionInfo{execution("wid3"), execution("wid4")},
+			}, nil
+		}).Times(4)
+
+	query := NewSearchAttributeKeyKeyword("Env").Equal("prod")
+	var ids []string
+	result, err := s.client.ListWorkflowExecutions(context.Background(), ClientListWorkflowExecutionsOptions{TypedQuery: query, PageSize: 2})
+	s.NoError(err)
+	for info, err := range result.Results {
+		s.NoError(err)
+		ids = append(ids, info.WorkflowExecution.ID)
+		s.Equal(workflowType, info.WorkflowType.Name)
+		var team string
+		s.NoError(info.GetMemoValue("team", &team))
+		s.Equal("data", team)
+		s.ErrorIs(info.GetMemoValue("missing", &team), ErrNoData)
+		env, _ := info.TypedSearchAttributes.GetKeyword(NewSearchAttributeKeyKeyword("Env"))
+		s.Equal("prod", env)
+	}
+	s.Equal([]string{"wid1", "wid2", "wid3", "wid4"}, ids)
+	s.Len(requests, 2)
+	s.Equal(DefaultNamespace, requests[0].Namespace)
+	s.Equal("Env = 'prod'", requests[0].Query)
+	s.Equal(int32(2), requests[0].PageSize)
+	s.Equal([]byte("page2"), requests[1].NextPageToken)
+
+	// MaxResults lowers the page size and stops iterating once reached.
+	ids = nil
+	result, err = s.client.ListWorkflowExecutions(context.Background(), ClientListWorkflowExecutionsOptions{PageSize: 10, MaxResults: 3})
+	s.NoError(err)
+	for info, err := range result.Results {
+		s.NoError(err)
+		ids = append(ids, info.WorkflowExecution.ID)
+	}
+	s.Equal([]string{"wid1", "wid2", "wid3"}, ids)
+	s.Equal(int32(3), requests[2].PageSize)
+
+	_, err = s.client.ListWorkflowExecutions(context.Background(), ClientListWorkflowExecutionsOptions{Query: "Env = 'prod'", TypedQuery: query})
+	s.ErrorContains(err, "only one of Query and TypedQuery can be set")
+
+	s.service.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewInvalidArgument("bad query"))
+	var errs []error
+	result, err = s.client.ListWorkflowExecutions(context.Background(), ClientListWorkflowExecutionsOptions{Query: "bad"})
+	s.NoError(err)
+	for info, err := range result.Results {
+		s.Nil(info)
+		errs = append(errs, err)
+	}
+	s.Len(errs, 1)
+	s.IsType(&serviceerror.InvalidArgument{}, errs[0])
+}
+
+func (s *workflowClientTestSuite) TestListArchivedWorkflowExecutions() {
+	s.service.EXPECT().ListArchivedWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *workflowservice.ListArchivedWorkflowExecutionsRequest, _ ...grpc.CallOption) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error) {
+			s.Equal(DefaultNamespace, req.Namespace)
+			s.Equal("WorkflowType = 'archived'", req.Query)
+			return &workflowservice.ListArchivedWorkflowExecutionsResponse{
+				Executions: []*workflowpb.WorkflowExecutionInfo{{
+					Execution: &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+					Status:    enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+				}},
+			}, nil
+		})
+	result, err := s.client.ListArchivedWorkflowExecutions(context.Background(), ClientListWorkflowExecutionsOptions{Query: "WorkflowType = 'archived'"})
+	s.NoError(err)
+	var infos []*ClientWorkflowExecutionInfo
+	for info, err := range result.Results {
+		s.NoError(err)
+		infos = append(infos, info)
+	}
+	s.Len(infos, 1)
+	s.Equal(WorkflowExecution{ID: workflowID, RunID: runID}, infos[0].WorkflowExecution)
+	s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, infos[0].Status)
+	s.ErrorIs(infos[0].GetMemoValue("team", new(string)), ErrNoData)
+}
+
+func (s *workflowClientTestSuite) TestListClosedWorkflowExecutions() {
+	earliest := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var requests []*workflowservice.ListClosedWorkflowExecutionsRequest
+	s.service.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *workflowservice.ListClosedWorkflowExecutionsRequest, _ ...grpc.CallOption) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
+			requests = append(requests, proto.Clone(req).(*workflowservice.ListClosedWorkflowExecutionsRequest))
+			if req.NextPageToken == nil {
+				return &workflowservice.ListClosedWorkflowExecutionsResponse{
+					Executions: []*workflowpb.WorkflowExecutionInfo{{
+						Execution: &commonpb.WorkflowExecution{WorkflowId: "wid1", RunId: runID},
+						Status:    enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+					}},
+					NextPageToken: []byte("page2"),
+				}, nil
+			}
+			return &workflowservice.ListClosedWorkflowExecutionsResponse{
+				Executions: []*workflowpb.WorkflowExecutionInfo{{
+					Execution: &commonpb.WorkflowExecution{WorkflowId: "wid2", RunId: runID},
+					Status:    enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+				}},
+			}, nil
+		}).Times(2)
+
+	result, err := s.client.ListClosedWorkflowExecutions(context.Background(), ClientListClosedWorkflowExecutionsOptions{
+		EarliestStartTime: earliest,
+		Status:            enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+		PageSize:          1,
+	})
+	s.NoError(err)
+	var ids []string
+	for info, err := range result.Results {
+		s.NoError(err)
+		s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, info.Status)
+		ids = append(ids, info.WorkflowExecution.ID)
+	}
+	s.Equal([]string{"wid1", "wid2"}, ids)
+	s.Len(requests, 2)
+	s.Equal(DefaultNamespace, requests[0].Namespace)
+	s.Equal(int32(1), requests[0].MaximumPageSize)
+	s.Equal(earliest, requests[0].GetStartTimeFilter().GetEarliestTime().AsTime())
+	s.Nil(requests[0].GetStartTimeFilter().GetLatestTime())
+	s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, requests[0].GetStatusFilter().GetStatus())
+	s.Equal([]byte("page2"), requests[1].NextPageToken)
+
+	_, err = s.client.ListClosedWorkflowExecutions(context.Background(), ClientListClosedWorkflowExecutionsOptions{
+		WorkflowID:   workflowID,
+		WorkflowType: workflowType,
+	})
+	s.Error(err)
+}
+
 func (s *workflowClientTestSuite) TestScanWorkflow() {
 	//lint:ignore SA1019 the server API was deprecated.
 	request := &workflowservice.ScanWorkflowExecutionsRequest{}
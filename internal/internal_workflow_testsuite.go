@@ -97,9 +97,11 @@ type (
 		cancelRequested bool
 		started         bool
 		done            bool
-		onCompleted     func(*commonpb.Payload, error)
-		onStarted       func(opID string, e error)
-		isMocked        bool
+		// callerResolved is set when the caller was unblocked by a cancel request before the operation completed.
+		callerResolved bool
+		onCompleted    func(*commonpb.Payload, error)
+		onStarted      func(opID string, e error)
+		isMocked       bool
 	}
 
 	testNexusAsyncOperationHandle struct {
@@ -253,6 +255,13 @@ type (
 		// True if this was created only for testing activities not workflows.
 		activityEnvOnly             bool
 		executeActivitiesInWorkflow bool
+		// True if this env runs no workflow of its own and hosts the workflows started by a fake client.
+		hostsFakeClient bool
+		// Closed once the workflow definition is executed and its handlers are set, for workflows of a fake client.
+		workflowDefExecuted chan struct{}
+		// Signals sent along with the start by a fake client, delivered once the handlers are set and before the
+		// first workflow task.
+		signalsWithStart []func()
 
 		workflowFunctionExecuting bool
 		bufferedUpdateRequests    map[string][]func()
@@ -466,6 +475,9 @@ func (env *testWorkflowEnvironmentImpl) newTestWorkflowEnvironmentForChild(
 	}
 	childEnv.registry = env.registry
 	childEnv.detachedChildWaitDisabled = env.detachedChildWaitDisabled
+	if env.hostsFakeClient {
+		childEnv.workflowDefExecuted = make(chan struct{})
+	}
 
 	if params.TaskQueueName == "" {
 		return nil, serviceerror.NewWorkflowExecutionAlreadyStarted("Empty task queue name", "", "")
@@ -490,12 +502,14 @@ func (env *testWorkflowEnvironmentImpl) newTestWorkflowEnvironmentForChild(
 	childEnv.workflowInfo.WorkflowTaskTimeout = params.WorkflowTaskTimeout
 	childEnv.workflowInfo.lastCompletionResult = params.lastCompletionResult
 	childEnv.workflowInfo.CronSchedule = cronSchedule
-	childEnv.workflowInfo.ParentWorkflowNamespace = env.workflowInfo.Namespace
-	childEnv.workflowInfo.ParentWorkflowExecution = &env.workflowInfo.WorkflowExecution
-	if env.workflowInfo.RootWorkflowExecution == nil {
-		childEnv.workflowInfo.RootWorkflowExecution = &env.workflowInfo.WorkflowExecution
-	} else {
-		childEnv.workflowInfo.RootWorkflowExecution = env.workflowInfo.RootWorkflowExecution
+	if !env.hostsFakeClient {
+		childEnv.workflowInfo.ParentWorkflowNamespace = env.workflowInfo.Namespace
+		childEnv.workflowInfo.ParentWorkflowExecution = &env.workflowInfo.WorkflowExecution
+		if env.workflowInfo.RootWorkflowExecution == nil {
+			childEnv.workflowInfo.RootWorkflowExecution = &env.workflowInfo.WorkflowExecution
+		} else {
+			childEnv.workflowInfo.RootWorkflowExecution = env.workflowInfo.RootWorkflowExecution
+		}
 	}
 
 	searchAttrs, err := serializeSearchAttributes(params.SearchAttributes, params.TypedSearchAttributes)
@@ -503,6 +517,12 @@ func (env *testWorkflowEnvironmentImpl) newTestWorkflowEnvironmentForChild(
 		return nil, err
 	}
 	childEnv.workflowInfo.SearchAttributes = searchAttrs
+	// Memo values may already be encoded, like those of schedule actions.
+	memo, err := encodeScheduleWorkflowMemo(childEnv.dataConverter, params.Memo)
+	if err != nil {
+		return nil, err
+	}
+	childEnv.workflowInfo.Memo = memo
 
 	childEnv.runTimeout = params.WorkflowRunTimeout
 	if workflowHandler, ok := env.runningWorkflows[params.WorkflowID]; ok {
@@ -648,6 +668,13 @@ func (env *testWorkflowEnvironmentImpl) executeWorkflowInternal(delayStart time.
 	// to make sure workflowDef.Execute() is run in main loop.
 	env.postCallback(func() {
 		env.workflowDef.Execute(env, env.header, input)
+		if env.workflowDefExecuted != nil {
+			close(env.workflowDefExecuted)
+		}
+		for _, signal := range env.signalsWithStart {
+			signal()
+		}
+		env.signalsWithStart = nil
 		// kick off first workflow task to start the workflow
 		if delayStart == 0 {
 			env.startWorkflowTask()
@@ -2637,13 +2664,14 @@ func (env *testWorkflowEnvironmentImpl) ExecuteChildWorkflow(params ExecuteWorkf
 	env.executeChildWorkflowWithDelay(0, params, callback, startedHandler)
 }
 
-func (env *testWorkflowEnvironmentImpl) executeChildWorkflowWithDelay(delayStart time.Duration, params ExecuteWorkflowParams, callback ResultHandler, startedHandler func(r WorkflowExecution, e error)) {
+// executeChildWorkflowWithDelay starts a child workflow and returns its environment, or nil if it was not started.
+func (env *testWorkflowEnvironmentImpl) executeChildWorkflowWithDelay(delayStart time.Duration, params ExecuteWorkflowParams, callback ResultHandler, startedHandler func(r WorkflowExecution, e error)) *testWorkflowEnvironmentImpl {
 	childEnv, err := env.newTestWorkflowEnvironmentForChild(&params, callback, startedHandler)
 	if err != nil {
 		env.logger.Info("ExecuteChildWorkflow failed", tagError, err)
 		startedHandler(WorkflowExecution{}, err)
 		callback(nil, err)
-		return
+		return nil
 	}
 
 	// childEnv can be nil when WorkflowIDConflictPolicy is USE_EXISTING and there's already a running
@@ -2655,6 +2683,7 @@ func (env *testWorkflowEnvironmentImpl) executeChildWorkflowWithDelay(delayStart
 		// run child workflow in separate goroutinue
 		go childEnv.executeWorkflowInternal(delayStart, params.WorkflowType.Name, params.Input)
 	}
+	return childEnv
 }
 
 func (env *testWorkflowEnvironmentImpl) newTestNexusTaskHandler(
@@ -2676,6 +2705,7 @@ func (env *testWorkflowEnvironmentImpl) newTestNexusTaskHandler(
 		env.logger,
 		env.metricsHandler,
 		env.registry,
+		env.workerOptions.NexusMiddleware,
 	)
 }
 
@@ -2871,6 +2901,13 @@ func (env *testWorkflowEnvironmentImpl) RequestCancelNexusOperation(seq int64) {
 	if handle.started {
 		handle.cancel()
 	}
+
+	// Like the server, don't wait for the cancel request to be delivered or the operation to complete.
+	if handle.params.options.CancellationType == NexusOperationCancellationTypeTryCancel {
+		env.postCallback(func() {
+			handle.resolveCanceled()
+		}, true)
+	}
 }
 
 func (env *testWorkflowEnvironmentImpl) RegisterNexusAsyncOperationCompletion(
@@ -3360,25 +3397,27 @@ func (env *testWorkflowEnvironmentImpl) updateWorkflowByID(workflowID, name, id
 			panic(err)
 		}
 
-		if env.updateMap == nil {
-			env.updateMap = make(map[string]*updateResult)
+		// Update IDs are deduplicated per workflow, like on the server.
+		workflowEnv := workflowHandle.env
+		if workflowEnv.updateMap == nil {
+			workflowEnv.updateMap = make(map[string]*updateResult)
 		}
 
-		var ucWrapper = updateCallbacksWrapper{uc: uc, env: env, updateID: id}
+		var ucWrapper = updateCallbacksWrapper{uc: uc, env: workflowEnv, updateID: id}
 
 		// Check for duplicate update ID
-		if result, ok := env.updateMap[id]; ok {
+		if result, ok := workflowEnv.updateMap[id]; ok {
 			if result.completed {
-				env.postCallback(func() {
+				workflowEnv.postCallback(func() {
 					ucWrapper.uc.Accept()
 					ucWrapper.uc.Complete(result.success, result.err)
 				}, false)
 			} else {
 				result.callbacks = append(result.callbacks, ucWrapper)
 			}
-			env.updateMap[id] = result
+			workflowEnv.updateMap[id] = result
 		} else {
-			env.updateMap[id] = &updateResult{nil, nil, id, []updateCallbacksWrapper{}, false}
+			workflowEnv.updateMap[id] = &updateResult{nil, nil, id, []updateCallbacksWrapper{}, false}
 			workflowHandle.env.postCallback(func() {
 				workflowHandle.env.updateHandler(name, id, data, nil, ucWrapper)
 			}, true)
@@ -3584,12 +3623,32 @@ func (h *testNexusOperationHandle) newStartTask() *workflowservice.PollNexusTask
 						"operation-sequence": strconv.FormatInt(h.seq, 10),
 					},
 					Payload: h.params.input,
+					Links:   []*nexuspb.Link{h.callerLink()},
 				},
 			},
 		},
 	}
 }
 
+// callerLink links to the caller workflow the same way the server does.
+func (h *testNexusOperationHandle) callerLink() *nexuspb.Link {
+	info := h.env.workflowInfo
+	link := ConvertLinkWorkflowEventToNexusLink(&commonpb.Link_WorkflowEvent{
+		Namespace:  info.Namespace,
+		WorkflowId: info.WorkflowExecution.ID,
+		RunId:      info.WorkflowExecution.RunID,
+		Reference: &commonpb.Link_WorkflowEvent_EventRef{
+			EventRef: &commonpb.Link_WorkflowEvent_EventReference{
+				EventType: enumspb.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED,
+			},
+		},
+	})
+	return &nexuspb.Link{
+		Url:  link.URL.String(),
+		Type: link.Type,
+	}
+}
+
 func (h *testNexusOperationHandle) newCancelTask() *workflowservice.PollNexusTaskQueueResponse {
 	return &workflowservice.PollNexusTaskQueueResponse{
 		TaskToken: []byte{},
@@ -3620,7 +3679,9 @@ func (h *testNexusOperationHandle) completedCallback(result *commonpb.Payload, e
 	}
 	h.done = true
 	h.env.deleteNexusOperationHandle(h.seq)
-	h.onCompleted(result, err)
+	if !h.callerResolved {
+		h.onCompleted(result, err)
+	}
 	if h.env.onNexusOperationCompletedListener != nil {
 		h.env.onNexusOperationCompletedListener(
 			h.params.client.Service(),
@@ -3643,7 +3704,9 @@ func (h *testNexusOperationHandle) startedCallback(token string, e error) {
 	}
 	h.operationToken = token
 	h.started = true
-	h.onStarted(token, e)
+	if !h.callerResolved {
+		h.onStarted(token, e)
+	}
 	h.env.runningCount--
 
 	// Start the StartToCloseTimeout timer if configured and operation started successfully
@@ -3706,10 +3769,29 @@ func (h *testNexusOperationHandle) cancel() {
 			if h.env.onNexusOperationCanceledListener != nil {
 				h.env.onNexusOperationCanceledListener(h.params.client.Service(), h.params.operation)
 			}
+			if err == nil && failure == nil && h.params.options.CancellationType == NexusOperationCancellationTypeWaitRequested {
+				// The cancel request was delivered, so don't wait for the operation to complete.
+				h.env.postCallback(func() {
+					h.resolveCanceled()
+				}, true)
+			}
 		}, false)
 	}()
 }
 
+// resolveCanceled unblocks the caller of an operation it requested to cancel without waiting for the operation to
+// complete, which keeps running until it completes on its own. Must be called in a postCallback block.
+func (h *testNexusOperationHandle) resolveCanceled() {
+	if h.done || h.callerResolved {
+		return
+	}
+	h.callerResolved = true
+	if !h.started {
+		h.onStarted("", ErrCanceled)
+	}
+	h.onCompleted(nil, ErrCanceled)
+}
+
 type testNexusHandler struct {
 	nexus.UnimplementedHandler
 
@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	"go.temporal.io/sdk/log"
+)
+
+// LogSamplingOptions configures sampling and deduplication of log lines
+// written through a worker's logger.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/worker.LogSamplingOptions]
+type LogSamplingOptions struct {
+	// Interval is the period over which lines are sampled. Zero disables
+	// sampling.
+	Interval time.Duration
+
+	// First is the number of lines with the same level and message logged
+	// per Interval before sampling starts.
+	First int
+
+	// Thereafter is the sampling rate once First lines have been logged in the
+	// current Interval: every Thereafter-th line is logged and the rest are
+	// dropped. Zero drops all lines past First.
+	Thereafter int
+
+	// DedupWindow drops lines identical in level, message and key-value pairs to
+	// a line logged less than this long ago. Zero disables deduplication.
+	DedupWindow time.Duration
+}
+
+const (
+	logDropCauseSampled   = "sampled"
+	logDropCauseDuplicate = "duplicate"
+)
+
+var _ log.Logger = (*samplingLogger)(nil)
+var _ log.WithLogger = (*samplingLogger)(nil)
+var _ log.WithSkipCallers = (*samplingLogger)(nil)
+
+// samplingLogger drops log lines per LogSamplingOptions before passing them
+// to the underlying logger. Loggers created with With share state.
+type samplingLogger struct {
+	logger log.Logger
+	state  *logSamplingState
+	// Formatted keyvals from With, used in the dedup key
+	prefix string
+}
+
+type logSamplingState struct {
+	options        LogSamplingOptions
+	metricsHandler metrics.Handler
+	now            func() time.Time
+
+	lock          sync.Mutex
+	intervalStart time.Time
+	// Keyed by level and message
+	counts map[string]int
+	// Keyed by level, message and keyvals
+	lastLogged map[string]time.Time
+	lastPruned time.Time
+}
+
+// newSamplingLogger returns the logger wrapped to sample and deduplicate lines
+// per the options, or the logger itself if the options disable both.
+func newSamplingLogger(logger log.Logger, options LogSamplingOptions, metricsHandler metrics.Handler) log.Logger {
+	if options.Interval <= 0 && options.DedupWindow <= 0 {
+		return logger
+	}
+	return &samplingLogger{
+		logger: log.Skip(logger, 1),
+		state: &logSamplingState{
+			options:        options,
+			metricsHandler: metricsHandler,
+			now:            time.Now,
+			counts:         map[string]int{},
+			lastLogged:     map[string]time.Time{},
+		},
+	}
+}
+
+func (l *samplingLogger) Debug(msg string, keyvals ...interface{}) {
+	if l.allow("DEBUG", msg, keyvals) {
+		l.logger.Debug(msg, keyvals...)
+	}
+}
+
+func (l *samplingLogger) Info(msg string, keyvals ...interface{}) {
+	if l.allow("INFO", msg, keyvals) {
+		l.logger.Info(msg, keyvals...)
+	}
+}
+
+func (l *samplingLogger) Warn(msg string, keyvals ...interface{}) {
+	if l.allow("WARN", msg, keyvals) {
+		l.logger.Warn(msg, keyvals...)
+	}
+}
+
+func (l *samplingLogger) Error(msg string, keyvals ...interface{}) {
+	if l.allow("ERROR", msg, keyvals) {
+		l.logger.Error(msg, keyvals...)
+	}
+}
+
+func (l *samplingLogger) With(keyvals ...interface{}) log.Logger {
+	prefix := l.prefix
+	if l.state.options.DedupWindow > 0 {
+		prefix += formatLogKeyvals(keyvals)
+	}
+	return &samplingLogger{logger: log.With(l.logger, keyvals...), state: l.state, prefix: prefix}
+}
+
+func (l *samplingLogger) WithCallerSkip(depth int) log.Logger {
+	if sl, ok := l.logger.(log.WithSkipCallers); ok {
+		return &samplingLogger{logger: sl.WithCallerSkip(depth), state: l.state, prefix: l.prefix}
+	}
+	return l
+}
+
+func (l *samplingLogger) allow(level, msg string, keyvals []interface{}) bool {
+	var dedupKey string
+	if l.state.options.DedupWindow > 0 {
+		dedupKey = level + "\x00" + msg + "\x00" + l.prefix + formatLogKeyvals(keyvals)
+	}
+	cause := l.state.check(level+"\x00"+msg, dedupKey)
+	if cause == "" {
+		return true
+	}
+	l.state.metricsHandler.WithTags(map[string]string{metrics.CauseTagName: cause}).
+		Counter(metrics.WorkerLogLinesDropped).Inc(1)
+	return false
+}
+
+// check returns the cause the line is dropped for, or an empty string if it
+// should be logged.
+func (s *logSamplingState) check(sampleKey, dedupKey string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+
+	if s.options.DedupWindow > 0 {
+		if now.Sub(s.lastPruned) >= s.options.DedupWindow {
+			for k, t := range s.lastLogged {
+				if now.Sub(t) >= s.options.DedupWindow {
+					delete(s.lastLogged, k)
+				}
+			}
+			s.lastPruned = now
+		}
+		if t, ok := s.lastLogged[dedupKey]; ok && now.Sub(t) < s.options.DedupWindow {
+			return logDropCauseDuplicate
+		}
+	}
+
+	if s.options.Interval > 0 {
+		if now.Sub(s.intervalStart) >= s.options.Interval {
+			clear(s.counts)
+			s.intervalStart = now
+		}
+		s.counts[sampleKey]++
+		n := s.counts[sampleKey]
+		if n > s.options.First && (s.options.Thereafter <= 0 || (n-s.options.First)%s.options.Thereafter != 0) {
+			return logDropCauseSampled
+		}
+	}
+
+	if s.options.DedupWindow > 0 {
+		s.lastLogged[dedupKey] = now
+	}
+	return ""
+}
+
+func formatLogKeyvals(keyvals []interface{}) string {
+	var b strings.Builder
+	for _, v := range keyvals {
+		b.WriteByte(0)
+		_, _ = fmt.Fprint(&b, v)
+	}
+	return b.String()
+}
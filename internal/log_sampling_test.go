@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	ilog "go.temporal.io/sdk/internal/log"
+	"go.temporal.io/sdk/log"
+)
+
+func TestSamplingLogger(t *testing.T) {
+	memLogger := ilog.NewMemoryLogger()
+	metricsHandler := metrics.NewCapturingHandler()
+	logger := newSamplingLogger(memLogger, LogSamplingOptions{
+		Interval:   time.Minute,
+		First:      2,
+		Thereafter: 3,
+	}, metricsHandler)
+	now := time.Now()
+	logger.(*samplingLogger).state.now = func() time.Time { return now }
+
+	// First 2, then every 3rd, counted per level and message across With
+	for i := 0; i < 8; i++ {
+		log.With(logger, "Iteration", i).Info("hot loop")
+	}
+	logger.Warn("hot loop")
+	require.Equal(t, []string{
+		"INFO  hot loop Iteration 0\n",
+		"INFO  hot loop Iteration 1\n",
+		"INFO  hot loop Iteration 4\n",
+		"INFO  hot loop Iteration 7\n",
+		"WARN  hot loop\n",
+	}, memLogger.Lines())
+	require.Len(t, metricsHandler.Counters(), 1)
+	require.Equal(t, metrics.WorkerLogLinesDropped, metricsHandler.Counters()[0].Name)
+	require.Equal(t, "sampled", metricsHandler.Counters()[0].Tags[metrics.CauseTagName])
+	require.Equal(t, int64(4), metricsHandler.Counters()[0].Value())
+
+	// Counts reset each interval
+	now = now.Add(time.Minute)
+	logger.Info("hot loop")
+	require.Len(t, memLogger.Lines(), 6)
+}
+
+func TestDedupLogger(t *testing.T) {
+	memLogger := ilog.NewMemoryLogger()
+	metricsHandler := metrics.NewCapturingHandler()
+	logger := newSamplingLogger(memLogger, LogSamplingOptions{DedupWindow: time.Minute}, metricsHandler)
+	now := time.Now()
+	logger.(*samplingLogger).state.now = func() time.Time { return now }
+
+	withLogger := log.With(logger, "ActivityID", "1")
+	withLogger.Error("retrying", "Attempt", 1)
+	withLogger.Error("retrying", "Attempt", 1)
+	withLogger.Error("retrying", "Attempt", 2)
+	log.With(logger, "ActivityID", "2").Error("retrying", "Attempt", 1)
+	now = now.Add(30 * time.Second)
+	withLogger.Error("retrying", "Attempt", 1)
+	now = now.Add(time.Minute)
+	withLogger.Error("retrying", "Attempt", 1)
+	require.Equal(t, []string{
+		"ERROR retrying ActivityID 1 Attempt 1\n",
+		"ERROR retrying ActivityID 1 Attempt 2\n",
+		"ERROR retrying ActivityID 2 Attempt 1\n",
+		"ERROR retrying ActivityID 1 Attempt 1\n",
+	}, memLogger.Lines())
+	require.Len(t, metricsHandler.Counters(), 1)
+	require.Equal(t, "duplicate", metricsHandler.Counters()[0].Tags[metrics.CauseTagName])
+	require.Equal(t, int64(2), metricsHandler.Counters()[0].Value())
+
+	// Disabled options return the logger as is
+	require.Equal(t, log.Logger(memLogger), newSamplingLogger(memLogger, LogSamplingOptions{}, metricsHandler))
+}
+
+func TestSamplingLoggerCallerDepth(t *testing.T) {
+	var buf bytes.Buffer
+	th := slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true})
+	logger := newSamplingLogger(log.NewStructuredLogger(slog.New(th)), LogSamplingOptions{DedupWindow: time.Minute},
+		metrics.NopHandler)
+	log.With(logger, "Key", "Value").Info("with caller")
+
+	var record struct {
+		Source struct {
+			Function string `json:"function"`
+		} `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "go.temporal.io/sdk/internal.TestSamplingLoggerCallerDepth", record.Source.Function)
+}
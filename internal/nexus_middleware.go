@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nexus-rpc/sdk-go/nexus"
+)
+
+type (
+	// NexusMiddlewareRequest describes a Nexus request to a worker before it is passed to the operation handler.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporalnexus.MiddlewareRequest]
+	NexusMiddlewareRequest struct {
+		// Service is the name of the Nexus service.
+		Service string
+		// Operation is the name of the Nexus operation.
+		Operation string
+		// Endpoint is the name of the Nexus endpoint the request was addressed to. Only set by servers that report it.
+		Endpoint string
+		// Header of the request.
+		Header nexus.Header
+		// Links sent by the caller, e.g. to the workflow event that scheduled the operation. Only set for start
+		// requests.
+		Links []nexus.Link
+		// StartOptions are the options passed to the operation handler for start requests, and nil otherwise. Changes
+		// to them are seen by the handler.
+		StartOptions *nexus.StartOperationOptions
+		// CancelOptions are the options passed to the operation handler for cancel requests, and nil otherwise.
+		// Changes to them are seen by the handler.
+		CancelOptions *nexus.CancelOperationOptions
+		// OperationToken is the token of the operation being canceled. Only set for cancel requests.
+		OperationToken string
+	}
+
+	// NexusMiddleware is called for each Nexus request handled by a worker, before the operation handler, in the
+	// order given in [WorkerOptions.NexusMiddleware]. It returns the context to pass on, which can carry values for
+	// the handler such as the caller's tenant, or an error to reject the request. A [*nexus.HandlerError] is returned
+	// to the caller as is and any other error as an internal handler error.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/temporalnexus.Middleware]
+	NexusMiddleware func(ctx context.Context, request *NexusMiddlewareRequest) (context.Context, error)
+)
+
+// applyNexusMiddleware calls the middleware in order, stopping at the first one that rejects the request.
+func applyNexusMiddleware(
+	ctx context.Context,
+	nctx *NexusOperationContext,
+	middleware []NexusMiddleware,
+	request *NexusMiddlewareRequest,
+) (context.Context, *nexus.HandlerError) {
+	for _, m := range middleware {
+		next, err := m(ctx, request)
+		if err != nil {
+			nctx.log.Warn("Nexus middleware rejected request", tagError, err)
+			var handlerErr *nexus.HandlerError
+			if errors.As(convertKnownErrors(err), &handlerErr) {
+				return nil, handlerErr
+			}
+			return nil, &nexus.HandlerError{
+				Type:    nexus.HandlerErrorTypeInternal,
+				Message: "internal handler error",
+				Cause:   err,
+			}
+		}
+		if next != nil {
+			ctx = next
+		}
+	}
+	return ctx, nil
+}
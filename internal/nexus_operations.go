@@ -659,6 +659,21 @@ func (t *testSuiteClientForNexusOperations) ListArchivedWorkflow(ctx context.Con
 	panic("not implemented in the test environment")
 }
 
+// ListWorkflowExecutions implements Client.
+func (t *testSuiteClientForNexusOperations) ListWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	panic("not implemented in the test environment")
+}
+
+// ListArchivedWorkflowExecutions implements Client.
+func (t *testSuiteClientForNexusOperations) ListArchivedWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	panic("not implemented in the test environment")
+}
+
+// ListClosedWorkflowExecutions implements Client.
+func (t *testSuiteClientForNexusOperations) ListClosedWorkflowExecutions(ctx context.Context, options ClientListClosedWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	panic("not implemented in the test environment")
+}
+
 // ListClosedWorkflow implements Client.
 func (t *testSuiteClientForNexusOperations) ListClosedWorkflow(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
 	panic("not implemented in the test environment")
@@ -696,6 +696,10 @@ type (
 		// Query - Filter results using a SQL-like query.
 		// Optional
 		Query string
+
+		// TypedQuery - Filter results using a query built from typed search attribute keys. Cannot be set with Query.
+		// Optional
+		TypedQuery VisibilityQuery
 	}
 
 	// ScheduleListIterator represents the interface for
@@ -0,0 +1,668 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/sdk/converter"
+)
+
+// ScheduleChangeKind is the kind of change a [SchedulePlan] makes to a schedule.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.ScheduleChangeKind]
+type ScheduleChangeKind int
+
+const (
+	// ScheduleChangeUnchanged - The schedule already matches the desired options.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleChangeUnchanged]
+	ScheduleChangeUnchanged ScheduleChangeKind = iota
+
+	// ScheduleChangeCreate - The schedule does not exist and is created.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleChangeCreate]
+	ScheduleChangeCreate
+
+	// ScheduleChangeUpdate - The schedule exists and differs from the desired options.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleChangeUpdate]
+	ScheduleChangeUpdate
+
+	// ScheduleChangeDelete - The schedule is owned by the reconciler but not desired, and is deleted.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleChangeDelete]
+	ScheduleChangeDelete
+)
+
+type (
+	// ScheduleReconcilerOptions are the options for [NewScheduleReconciler].
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleReconcilerOptions]
+	ScheduleReconcilerOptions struct {
+		// Owner is the label of the schedules managed by the reconciler. Required if OwnerMemoKey or
+		// OwnerSearchAttribute is set.
+		Owner string
+
+		// OwnerMemoKey - If set, only schedules whose memo has this key set to Owner are managed. Created schedules get
+		// this memo.
+		OwnerMemoKey string
+
+		// OwnerSearchAttribute - If set, only schedules with this search attribute set to Owner are managed. Created
+		// and updated schedules get this search attribute, which must be registered on the namespace. Unlike
+		// OwnerMemoKey, it filters the listed schedules on the server.
+		OwnerSearchAttribute SearchAttributeKeyKeyword
+
+		// Query - Optional visibility query further limiting the schedules that are deleted when pruning.
+		Query string
+
+		// Prune - If true, managed schedules that are not desired are deleted. Requires OwnerMemoKey,
+		// OwnerSearchAttribute or Query, so that schedules created by others are not deleted.
+		Prune bool
+
+		// DataConverter - Used to compare workflow arguments and memos with the existing ones.
+		// Optional: defaults to the data converter of the client.
+		DataConverter converter.DataConverter
+	}
+
+	// ScheduleReconciler keeps the schedules of a namespace in sync with a desired set of [ScheduleOptions]. Create
+	// with [NewScheduleReconciler].
+	//
+	// Specs are compared by the times they match, so a cron expression is unchanged from the calendar the server
+	// stores for it. Create-only options (TriggerImmediately, ScheduleBackfill, Memo and untyped SearchAttributes) and
+	// the workflow action priority, which the server does not return, are not compared. RemainingActions is only
+	// compared by whether actions are limited, since the server counts it down.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleReconciler]
+	ScheduleReconciler struct {
+		client        ScheduleClient
+		options       ScheduleReconcilerOptions
+		namespace     string
+		registry      *registry
+		dataConverter converter.DataConverter
+	}
+
+	// SchedulePlan is the set of changes that reconciles schedules with their desired options, ordered by schedule
+	// ID.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.SchedulePlan]
+	SchedulePlan struct {
+		Changes []ScheduleChange
+	}
+
+	// ScheduleChange is the change a [SchedulePlan] makes to one schedule.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleChange]
+	ScheduleChange struct {
+		ID   string
+		Kind ScheduleChangeKind
+		// Diffs are the fields changed by an update.
+		Diffs []ScheduleFieldDiff
+		// Options are the desired options of the schedule, nil for a delete.
+		Options *ScheduleOptions
+	}
+
+	// ScheduleFieldDiff is a field of a schedule that differs from its desired value.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleFieldDiff]
+	ScheduleFieldDiff struct {
+		// Field is the path of the field in the schedule description, like "Spec.Calendars" or "Action.TaskQueue".
+		Field   string
+		Current interface{}
+		Desired interface{}
+	}
+)
+
+// String returns the name of the change kind.
+func (k ScheduleChangeKind) String() string {
+	switch k {
+	case ScheduleChangeUnchanged:
+		return "Unchanged"
+	case ScheduleChangeCreate:
+		return "Create"
+	case ScheduleChangeUpdate:
+		return "Update"
+	case ScheduleChangeDelete:
+		return "Delete"
+	}
+	return fmt.Sprintf("ScheduleChangeKind(%d)", int(k))
+}
+
+// HasChanges reports whether applying the plan changes any schedule.
+func (p *SchedulePlan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.Kind != ScheduleChangeUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// NewScheduleReconciler creates a reconciler for the schedules of the client.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.NewScheduleReconciler]
+func NewScheduleReconciler(client ScheduleClient, options ScheduleReconcilerOptions) (*ScheduleReconciler, error) {
+	if client == nil {
+		return nil, errors.New("schedule client is required")
+	}
+	if options.Owner == "" && (options.OwnerMemoKey != "" || options.OwnerSearchAttribute.GetName() != "") {
+		return nil, errors.New("owner is required with an owner memo key or search attribute")
+	}
+	if options.Prune && options.OwnerMemoKey == "" && options.OwnerSearchAttribute.GetName() == "" && options.Query == "" {
+		return nil, errors.New("prune requires an owner memo key, owner search attribute or query")
+	}
+	r := &ScheduleReconciler{
+		client:        client,
+		options:       options,
+		registry:      newRegistry(),
+		dataConverter: options.DataConverter,
+	}
+	if sc, ok := client.(*scheduleClient); ok {
+		r.namespace = sc.workflowClient.namespace
+		r.registry = sc.workflowClient.registry
+		if r.dataConverter == nil {
+			r.dataConverter = sc.workflowClient.dataConverter
+		}
+	}
+	if r.dataConverter == nil {
+		r.dataConverter = converter.GetDefaultDataConverter()
+	}
+	return r, nil
+}
+
+// Plan compares the desired schedules, keyed by ID, with the existing ones. A desired schedule that exists but is
+// not owned by the reconciler is an error.
+func (r *ScheduleReconciler) Plan(ctx context.Context, desired map[string]ScheduleOptions) (*SchedulePlan, error) {
+	plan := &SchedulePlan{}
+	for id, options := range desired {
+		if options.ID == "" {
+			options.ID = id
+		} else if options.ID != id {
+			return nil, fmt.Errorf("schedule %q has options for ID %q", id, options.ID)
+		}
+		action, ok := options.Action.(*ScheduleWorkflowAction)
+		if !ok {
+			return nil, fmt.Errorf("schedule %q must have a workflow action", id)
+		}
+		if action.ID == "" {
+			// A generated ID would differ on every update.
+			return nil, fmt.Errorf("schedule %q must have a workflow action ID", id)
+		}
+
+		change := ScheduleChange{ID: id, Options: &options}
+		description, err := r.client.GetHandle(ctx, id).Describe(ctx)
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			change.Kind = ScheduleChangeCreate
+		} else if err != nil {
+			return nil, fmt.Errorf("failed describing schedule %q: %w", id, err)
+		} else if !r.ownsDescription(description) {
+			return nil, fmt.Errorf("schedule %q exists but is not owned by %q", id, r.options.Owner)
+		} else {
+			if change.Diffs, err = r.diff(description, &options); err != nil {
+				return nil, fmt.Errorf("failed comparing schedule %q: %w", id, err)
+			}
+			if len(change.Diffs) > 0 {
+				change.Kind = ScheduleChangeUpdate
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if r.options.Prune {
+		iter, err := r.client.List(ctx, ScheduleListOptions{Query: r.listQuery()})
+		if err != nil {
+			return nil, err
+		}
+		seen := map[string]bool{}
+		for iter.HasNext() {
+			entry, err := iter.Next()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := desired[entry.ID]; ok || seen[entry.ID] || !r.ownsMemo(entry.Memo) {
+				continue
+			}
+			seen[entry.ID] = true
+			plan.Changes = append(plan.Changes, ScheduleChange{ID: entry.ID, Kind: ScheduleChangeDelete})
+		}
+	}
+
+	sort.Slice(plan.Changes, func(i, j int) bool { return plan.Changes[i].ID < plan.Changes[j].ID })
+	return plan, nil
+}
+
+// Apply makes the changes of the plan. It is idempotent: creating a schedule that already exists, updating one that
+// already has the desired options and deleting one that no longer exists succeed. All changes are attempted, and
+// the errors of the failed ones are joined.
+func (r *ScheduleReconciler) Apply(ctx context.Context, plan *SchedulePlan) error {
+	var errs []error
+	for _, change := range plan.Changes {
+		if err := r.apply(ctx, change); err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s schedule %q: %w", strings.ToLower(change.Kind.String()), change.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reconcile plans and applies the changes that bring the schedules in sync with the desired ones. The plan is
+// returned even if applying it fails.
+func (r *ScheduleReconciler) Reconcile(ctx context.Context, desired map[string]ScheduleOptions) (*SchedulePlan, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	return plan, r.Apply(ctx, plan)
+}
+
+func (r *ScheduleReconciler) apply(ctx context.Context, change ScheduleChange) error {
+	handle := r.client.GetHandle(ctx, change.ID)
+	switch change.Kind {
+	case ScheduleChangeCreate:
+		options := *change.Options
+		if r.options.OwnerMemoKey != "" {
+			options.Memo = make(map[string]interface{}, len(change.Options.Memo)+1)
+			for k, v := range change.Options.Memo {
+				options.Memo[k] = v
+			}
+			options.Memo[r.options.OwnerMemoKey] = r.options.Owner
+		}
+		options.TypedSearchAttributes = r.searchAttributes(change.Options)
+		_, err := r.client.Create(ctx, options)
+		if !errors.Is(err, ErrScheduleAlreadyRunning) {
+			return err
+		}
+		// Created since the plan, possibly with other options.
+		return r.update(ctx, handle, change.Options)
+	case ScheduleChangeUpdate:
+		return r.update(ctx, handle, change.Options)
+	case ScheduleChangeDelete:
+		err := handle.Delete(ctx)
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// update updates the schedule to the desired options unless it already matches them or is not owned by the
+// reconciler.
+func (r *ScheduleReconciler) update(ctx context.Context, handle ScheduleHandle, options *ScheduleOptions) error {
+	return handle.Update(ctx, ScheduleUpdateOptions{
+		DoUpdate: func(input ScheduleUpdateInput) (*ScheduleUpdate, error) {
+			if !r.ownsDescription(&input.Description) {
+				return nil, fmt.Errorf("schedule %q exists but is not owned by %q", handle.GetID(), r.options.Owner)
+			}
+			diffs, err := r.diff(&input.Description, options)
+			if err != nil {
+				return nil, err
+			}
+			if len(diffs) == 0 {
+				return nil, ErrSkipScheduleUpdate
+			}
+			return r.scheduleUpdate(&input.Description, options), nil
+		},
+	})
+}
+
+func (r *ScheduleReconciler) scheduleUpdate(description *ScheduleDescription, options *ScheduleOptions) *ScheduleUpdate {
+	spec := options.Spec
+	policy := &SchedulePolicies{
+		Overlap:        options.Overlap,
+		CatchupWindow:  options.CatchupWindow,
+		PauseOnFailure: options.PauseOnFailure,
+	}
+	if policy.CatchupWindow == 0 && description.Schedule.Policy != nil {
+		policy.CatchupWindow = description.Schedule.Policy.CatchupWindow
+	}
+	state := &ScheduleState{
+		Note:             options.Note,
+		Paused:           options.Paused,
+		LimitedActions:   options.RemainingActions != 0,
+		RemainingActions: options.RemainingActions,
+	}
+	if current := description.Schedule.State; current != nil && current.LimitedActions && state.LimitedActions {
+		state.RemainingActions = current.RemainingActions
+	}
+	searchAttributes := r.searchAttributes(options)
+	return &ScheduleUpdate{
+		Schedule: &Schedule{
+			Action: options.Action,
+			Spec:   &spec,
+			Policy: policy,
+			State:  state,
+		},
+		TypedSearchAttributes: &searchAttributes,
+	}
+}
+
+// searchAttributes returns the desired search attributes of the schedule, including the owner label.
+func (r *ScheduleReconciler) searchAttributes(options *ScheduleOptions) SearchAttributes {
+	if r.options.OwnerSearchAttribute.GetName() == "" {
+		return options.TypedSearchAttributes
+	}
+	return NewSearchAttributes(options.TypedSearchAttributes.Copy(), r.options.OwnerSearchAttribute.ValueSet(r.options.Owner))
+}
+
+func (r *ScheduleReconciler) ownsDescription(description *ScheduleDescription) bool {
+	if key := r.options.OwnerSearchAttribute; key.GetName() != "" {
+		if owner, _ := description.TypedSearchAttributes.GetKeyword(key); owner != r.options.Owner {
+			return false
+		}
+	}
+	return r.ownsMemo(description.Memo)
+}
+
+func (r *ScheduleReconciler) ownsMemo(memo *commonpb.Memo) bool {
+	if r.options.OwnerMemoKey == "" {
+		return true
+	}
+	payload := memo.GetFields()[r.options.OwnerMemoKey]
+	if payload == nil {
+		return false
+	}
+	var owner string
+	return r.dataConverter.FromPayload(payload, &owner) == nil && owner == r.options.Owner
+}
+
+func (r *ScheduleReconciler) listQuery() string {
+	var clauses []string
+	if r.options.Query != "" {
+		clauses = append(clauses, "("+r.options.Query+")")
+	}
+	if r.options.OwnerSearchAttribute.GetName() != "" {
+		clauses = append(clauses, r.options.OwnerSearchAttribute.Equal(r.options.Owner).String())
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// diff returns the fields of the described schedule that differ from the desired options.
+func (r *ScheduleReconciler) diff(description *ScheduleDescription, options *ScheduleOptions) ([]ScheduleFieldDiff, error) {
+	var diffs []ScheduleFieldDiff
+	add := func(field string, current, desired interface{}) {
+		diffs = append(diffs, ScheduleFieldDiff{Field: field, Current: current, Desired: desired})
+	}
+
+	currentSpec := ScheduleSpec{}
+	if description.Schedule.Spec != nil {
+		currentSpec = *description.Schedule.Spec
+	}
+	specDiffs, err := diffScheduleSpecs(currentSpec, options.Spec)
+	if err != nil {
+		return nil, err
+	}
+	diffs = append(diffs, specDiffs...)
+
+	currentAction, ok := description.Schedule.Action.(*ScheduleWorkflowAction)
+	if !ok {
+		add("Action", description.Schedule.Action, options.Action)
+	} else {
+		actionDiffs, err := r.diffActions(currentAction, options.Action.(*ScheduleWorkflowAction))
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, actionDiffs...)
+	}
+
+	policy := SchedulePolicies{}
+	if description.Schedule.Policy != nil {
+		policy = *description.Schedule.Policy
+	}
+	if scheduleOverlapPolicy(policy.Overlap) != scheduleOverlapPolicy(options.Overlap) {
+		add("Policy.Overlap", policy.Overlap, options.Overlap)
+	}
+	// Without a catchup window the server picks one.
+	if options.CatchupWindow != 0 && policy.CatchupWindow != options.CatchupWindow {
+		add("Policy.CatchupWindow", policy.CatchupWindow, options.CatchupWindow)
+	}
+	if policy.PauseOnFailure != options.PauseOnFailure {
+		add("Policy.PauseOnFailure", policy.PauseOnFailure, options.PauseOnFailure)
+	}
+
+	state := ScheduleState{}
+	if description.Schedule.State != nil {
+		state = *description.Schedule.State
+	}
+	if state.Note != options.Note {
+		add("State.Note", state.Note, options.Note)
+	}
+	if state.Paused != options.Paused {
+		add("State.Paused", state.Paused, options.Paused)
+	}
+	if state.LimitedActions != (options.RemainingActions != 0) {
+		add("State.RemainingActions", state.RemainingActions, options.RemainingActions)
+	}
+
+	// Search attributes of the server, like TemporalSchedulePaused, are not managed.
+	currentSearchAttributes := SearchAttributes{untypedValue: map[SearchAttributeKey]interface{}{}}
+	for key, value := range description.TypedSearchAttributes.GetUntypedValues() {
+		if !strings.HasPrefix(key.GetName(), "Temporal") {
+			currentSearchAttributes.untypedValue[key] = value
+		}
+	}
+	desiredSearchAttributes := r.searchAttributes(options)
+	equal, err := typedSearchAttributesEqual(currentSearchAttributes, desiredSearchAttributes)
+	if err != nil {
+		return nil, err
+	}
+	if !equal {
+		add("TypedSearchAttributes", currentSearchAttributes, desiredSearchAttributes)
+	}
+	return diffs, nil
+}
+
+func (r *ScheduleReconciler) diffActions(current, desired *ScheduleWorkflowAction) ([]ScheduleFieldDiff, error) {
+	var diffs []ScheduleFieldDiff
+	add := func(field string, current, desired interface{}) {
+		diffs = append(diffs, ScheduleFieldDiff{Field: "Action." + field, Current: current, Desired: desired})
+	}
+
+	if current.ID != desired.ID {
+		add("ID", current.ID, desired.ID)
+	}
+	currentType, err := getWorkflowFunctionName(r.registry, current.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	desiredType, err := getWorkflowFunctionName(r.registry, desired.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	if currentType != desiredType {
+		add("Workflow", currentType, desiredType)
+	}
+
+	dc := converter.WithDataConverterSerializationContext(r.dataConverter, converter.WorkflowSerializationContext{
+		Namespace:  r.namespace,
+		WorkflowID: desired.ID,
+	})
+	currentArgs, err := encodeScheduleWorklowArgs(dc, current.Args)
+	if err != nil {
+		return nil, err
+	}
+	desiredArgs, err := encodeScheduleWorklowArgs(dc, desired.Args)
+	if err != nil {
+		return nil, err
+	}
+	if !proto.Equal(currentArgs, desiredArgs) {
+		add("Args", current.Args, desired.Args)
+	}
+
+	if current.TaskQueue != desired.TaskQueue {
+		add("TaskQueue", current.TaskQueue, desired.TaskQueue)
+	}
+	if current.WorkflowExecutionTimeout != desired.WorkflowExecutionTimeout {
+		add("WorkflowExecutionTimeout", current.WorkflowExecutionTimeout, desired.WorkflowExecutionTimeout)
+	}
+	if current.WorkflowRunTimeout != desired.WorkflowRunTimeout {
+		add("WorkflowRunTimeout", current.WorkflowRunTimeout, desired.WorkflowRunTimeout)
+	}
+	if current.WorkflowTaskTimeout != desired.WorkflowTaskTimeout {
+		add("WorkflowTaskTimeout", current.WorkflowTaskTimeout, desired.WorkflowTaskTimeout)
+	}
+	if !proto.Equal(convertToPBRetryPolicy(current.RetryPolicy), convertToPBRetryPolicy(desired.RetryPolicy)) {
+		add("RetryPolicy", current.RetryPolicy, desired.RetryPolicy)
+	}
+
+	currentMemo, err := encodeScheduleWorkflowMemo(dc, current.Memo)
+	if err != nil {
+		return nil, err
+	}
+	desiredMemo, err := encodeScheduleWorkflowMemo(dc, desired.Memo)
+	if err != nil {
+		return nil, err
+	}
+	if !payloadMapsEqual(currentMemo.GetFields(), desiredMemo.GetFields()) {
+		add("Memo", current.Memo, desired.Memo)
+	}
+
+	equal, err := typedSearchAttributesEqual(current.TypedSearchAttributes, desired.TypedSearchAttributes)
+	if err != nil {
+		return nil, err
+	}
+	if !equal {
+		add("TypedSearchAttributes", current.TypedSearchAttributes, desired.TypedSearchAttributes)
+	}
+	if !payloadMapsEqual(current.UntypedSearchAttributes, desired.UntypedSearchAttributes) {
+		add("UntypedSearchAttributes", current.UntypedSearchAttributes, desired.UntypedSearchAttributes)
+	}
+
+	if current.StaticSummary != desired.StaticSummary {
+		add("StaticSummary", current.StaticSummary, desired.StaticSummary)
+	}
+	if current.StaticDetails != desired.StaticDetails {
+		add("StaticDetails", current.StaticDetails, desired.StaticDetails)
+	}
+	if !proto.Equal(versioningOverrideToProto(current.VersioningOverride), versioningOverrideToProto(desired.VersioningOverride)) {
+		add("VersioningOverride", current.VersioningOverride, desired.VersioningOverride)
+	}
+	return diffs, nil
+}
+
+// diffScheduleSpecs compares specs by the times they match rather than by how they are written.
+func diffScheduleSpecs(current, desired ScheduleSpec) ([]ScheduleFieldDiff, error) {
+	currentEvaluator, err := NewScheduleSpecEvaluator(current)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current spec: %w", err)
+	}
+	desiredEvaluator, err := NewScheduleSpecEvaluator(desired)
+	if err != nil {
+		return nil, fmt.Errorf("invalid desired spec: %w", err)
+	}
+	// Report the calendars and intervals the server stores for cron expressions.
+	desired, err = expandScheduleCronExpressions(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []ScheduleFieldDiff
+	add := func(field string, current, desired interface{}) {
+		diffs = append(diffs, ScheduleFieldDiff{Field: "Spec." + field, Current: current, Desired: desired})
+	}
+	calendarsEqual := func(a, b *compiledScheduleCalendar) bool { return a.equal(b) }
+	if !unorderedSlicesEqual(currentEvaluator.calendars, desiredEvaluator.calendars, calendarsEqual) {
+		add("Calendars", current.Calendars, desired.Calendars)
+	}
+	intervalsEqual := func(a, b ScheduleIntervalSpec) bool { return a == b }
+	if !unorderedSlicesEqual(currentEvaluator.intervals, desiredEvaluator.intervals, intervalsEqual) {
+		add("Intervals", current.Intervals, desired.Intervals)
+	}
+	if !unorderedSlicesEqual(currentEvaluator.skip, desiredEvaluator.skip, calendarsEqual) {
+		add("Skip", current.Skip, desired.Skip)
+	}
+	if !current.StartAt.Equal(desired.StartAt) {
+		add("StartAt", current.StartAt, desired.StartAt)
+	}
+	if !current.EndAt.Equal(desired.EndAt) {
+		add("EndAt", current.EndAt, desired.EndAt)
+	}
+	if current.Jitter != desired.Jitter {
+		add("Jitter", current.Jitter, desired.Jitter)
+	}
+	if currentEvaluator.location.String() != desiredEvaluator.location.String() {
+		add("TimeZoneName", current.TimeZoneName, desired.TimeZoneName)
+	}
+	return diffs, nil
+}
+
+// scheduleOverlapPolicy returns the overlap policy the server applies for the given one.
+func scheduleOverlapPolicy(policy enumspb.ScheduleOverlapPolicy) enumspb.ScheduleOverlapPolicy {
+	if policy == enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED {
+		return enumspb.SCHEDULE_OVERLAP_POLICY_SKIP
+	}
+	return policy
+}
+
+func typedSearchAttributesEqual(a, b SearchAttributes) (bool, error) {
+	serializedA, err := serializeTypedSearchAttributes(a.GetUntypedValues())
+	if err != nil {
+		return false, err
+	}
+	serializedB, err := serializeTypedSearchAttributes(b.GetUntypedValues())
+	if err != nil {
+		return false, err
+	}
+	return payloadMapsEqual(serializedA.GetIndexedFields(), serializedB.GetIndexedFields()), nil
+}
+
+func payloadMapsEqual(a, b map[string]*commonpb.Payload) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, payload := range a {
+		other, ok := b[key]
+		if !ok || !proto.Equal(payload, other) {
+			return false
+		}
+	}
+	return true
+}
+
+func unorderedSlicesEqual[T any](a, b []T, equal func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	matched := make([]bool, len(b))
+outer:
+	for _, x := range a {
+		for i, y := range b {
+			if !matched[i] && equal(x, y) {
+				matched[i] = true
+				continue outer
+			}
+		}
+		return false
+	}
+	return true
+}
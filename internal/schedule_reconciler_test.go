@@ -0,0 +1,349 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/sdk/converter"
+)
+
+// reconcilerTestScheduleClient stores schedules the way the server returns them: cron expressions become calendars
+// and workflow arguments and memos become payloads.
+type reconcilerTestScheduleClient struct {
+	schedules map[string]*ScheduleDescription
+	updates   int
+	queries   []string
+}
+
+type reconcilerTestScheduleHandle struct {
+	ScheduleHandle
+	client *reconcilerTestScheduleClient
+	id     string
+}
+
+type reconcilerTestScheduleListIterator struct {
+	entries []*ScheduleListEntry
+}
+
+func (c *reconcilerTestScheduleClient) Create(_ context.Context, options ScheduleOptions) (ScheduleHandle, error) {
+	if _, ok := c.schedules[options.ID]; ok {
+		return nil, ErrScheduleAlreadyRunning
+	}
+	memo, err := encodeScheduleWorkflowMemo(converter.GetDefaultDataConverter(), options.Memo)
+	if err != nil {
+		return nil, err
+	}
+	description, err := reconcilerTestDescription(Schedule{
+		Action: options.Action,
+		Spec:   &options.Spec,
+		Policy: &SchedulePolicies{
+			Overlap:        options.Overlap,
+			CatchupWindow:  options.CatchupWindow,
+			PauseOnFailure: options.PauseOnFailure,
+		},
+		State: &ScheduleState{
+			Note:             options.Note,
+			Paused:           options.Paused,
+			LimitedActions:   options.RemainingActions != 0,
+			RemainingActions: options.RemainingActions,
+		},
+	}, options.TypedSearchAttributes)
+	if err != nil {
+		return nil, err
+	}
+	description.Memo = memo
+	c.schedules[options.ID] = description
+	return c.GetHandle(context.Background(), options.ID), nil
+}
+
+func (c *reconcilerTestScheduleClient) List(_ context.Context, options ScheduleListOptions) (ScheduleListIterator, error) {
+	c.queries = append(c.queries, options.Query)
+	iter := &reconcilerTestScheduleListIterator{}
+	for id, description := range c.schedules {
+		iter.entries = append(iter.entries, &ScheduleListEntry{ID: id, Memo: description.Memo})
+	}
+	return iter, nil
+}
+
+func (c *reconcilerTestScheduleClient) GetHandle(_ context.Context, scheduleID string) ScheduleHandle {
+	return &reconcilerTestScheduleHandle{client: c, id: scheduleID}
+}
+
+func (h *reconcilerTestScheduleHandle) GetID() string {
+	return h.id
+}
+
+func (h *reconcilerTestScheduleHandle) Describe(context.Context) (*ScheduleDescription, error) {
+	description, ok := h.client.schedules[h.id]
+	if !ok {
+		return nil, serviceerror.NewNotFound("schedule not found")
+	}
+	return description, nil
+}
+
+func (h *reconcilerTestScheduleHandle) Update(ctx context.Context, options ScheduleUpdateOptions) error {
+	description, err := h.Describe(ctx)
+	if err != nil {
+		return err
+	}
+	update, err := options.DoUpdate(ScheduleUpdateInput{Description: *description})
+	if errors.Is(err, ErrSkipScheduleUpdate) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	updated, err := reconcilerTestDescription(*update.Schedule, *update.TypedSearchAttributes)
+	if err != nil {
+		return err
+	}
+	updated.Memo = description.Memo
+	h.client.schedules[h.id] = updated
+	h.client.updates++
+	return nil
+}
+
+func (h *reconcilerTestScheduleHandle) Delete(ctx context.Context) error {
+	if _, err := h.Describe(ctx); err != nil {
+		return err
+	}
+	delete(h.client.schedules, h.id)
+	return nil
+}
+
+func (iter *reconcilerTestScheduleListIterator) HasNext() bool {
+	return len(iter.entries) > 0
+}
+
+func (iter *reconcilerTestScheduleListIterator) Next() (*ScheduleListEntry, error) {
+	entry := iter.entries[0]
+	iter.entries = iter.entries[1:]
+	return entry, nil
+}
+
+func reconcilerTestDescription(schedule Schedule, searchAttributes SearchAttributes) (*ScheduleDescription, error) {
+	spec, err := expandScheduleCronExpressions(*schedule.Spec)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Spec = convertFromPBScheduleSpec(convertToPBScheduleSpec(&spec))
+
+	action := *schedule.Action.(*ScheduleWorkflowAction)
+	dc := converter.GetDefaultDataConverter()
+	if action.Workflow, err = getWorkflowFunctionName(newRegistry(), action.Workflow); err != nil {
+		return nil, err
+	}
+	input, err := encodeScheduleWorklowArgs(dc, action.Args)
+	if err != nil {
+		return nil, err
+	}
+	action.Args = nil
+	for _, payload := range input.Payloads {
+		action.Args = append(action.Args, payload)
+	}
+	memo, err := encodeScheduleWorkflowMemo(dc, action.Memo)
+	if err != nil {
+		return nil, err
+	}
+	action.Memo = map[string]interface{}{}
+	for k, payload := range memo.GetFields() {
+		action.Memo[k] = payload
+	}
+	schedule.Action = &action
+
+	// The server adds its own search attributes.
+	searchAttributes = NewSearchAttributes(
+		searchAttributes.Copy(),
+		NewSearchAttributeKeyBool("TemporalSchedulePaused").ValueSet(schedule.State.Paused),
+	)
+	return &ScheduleDescription{Schedule: schedule, TypedSearchAttributes: searchAttributes}, nil
+}
+
+func reconcilerTestWorkflow(Context, string) error {
+	return nil
+}
+
+func reconcilerTestSchedules(taskQueue string) map[string]ScheduleOptions {
+	return map[string]ScheduleOptions{
+		"nightly": {
+			Spec: ScheduleSpec{CronExpressions: []string{"CRON_TZ=America/New_York 0 2 * * *"}},
+			Action: &ScheduleWorkflowAction{
+				ID:        "nightly-workflow",
+				Workflow:  reconcilerTestWorkflow,
+				Args:      []interface{}{"nightly"},
+				TaskQueue: taskQueue,
+				Memo:      map[string]interface{}{"team": "data"},
+			},
+			Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+		},
+		"hourly": {
+			Spec: ScheduleSpec{
+				Intervals: []ScheduleIntervalSpec{{Every: time.Hour}},
+				Calendars: []ScheduleCalendarSpec{{Hour: []ScheduleRange{{Start: 9, End: 17}}}},
+			},
+			Action: &ScheduleWorkflowAction{
+				ID:        "hourly-workflow",
+				Workflow:  "ReconcilerTestWorkflow",
+				TaskQueue: taskQueue,
+			},
+			RemainingActions: 10,
+		},
+	}
+}
+
+func reconcilerTestKinds(plan *SchedulePlan) map[string]ScheduleChangeKind {
+	kinds := map[string]ScheduleChangeKind{}
+	for _, change := range plan.Changes {
+		kinds[change.ID] = change.Kind
+	}
+	return kinds
+}
+
+func TestScheduleReconciler_PlanAndApply(t *testing.T) {
+	ctx := context.Background()
+	client := &reconcilerTestScheduleClient{schedules: map[string]*ScheduleDescription{}}
+	reconciler, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{})
+	require.NoError(t, err)
+
+	plan, err := reconciler.Reconcile(ctx, reconcilerTestSchedules("tq"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]ScheduleChangeKind{
+		"hourly":  ScheduleChangeCreate,
+		"nightly": ScheduleChangeCreate,
+	}, reconcilerTestKinds(plan))
+	require.Len(t, client.schedules, 2)
+
+	// The schedules as returned by the server match the options they were created with.
+	plan, err = reconciler.Plan(ctx, reconcilerTestSchedules("tq"))
+	require.NoError(t, err)
+	require.False(t, plan.HasChanges())
+
+	// Actions were counted down by the server.
+	client.schedules["hourly"].Schedule.State.RemainingActions = 3
+	desired := reconcilerTestSchedules("tq2")
+	nightly := desired["nightly"]
+	nightly.Spec.CronExpressions = []string{"CRON_TZ=America/New_York 0 3 * * *"}
+	desired["nightly"] = nightly
+	plan, err = reconciler.Plan(ctx, desired)
+	require.NoError(t, err)
+	require.Equal(t, "hourly", plan.Changes[0].ID)
+	require.Equal(t, []ScheduleFieldDiff{{Field: "Action.TaskQueue", Current: "tq", Desired: "tq2"}}, plan.Changes[0].Diffs)
+	require.Equal(t, ScheduleChangeUpdate, plan.Changes[1].Kind)
+	require.Len(t, plan.Changes[1].Diffs, 2)
+	require.Equal(t, "Spec.Calendars", plan.Changes[1].Diffs[0].Field)
+	require.Equal(t, "Action.TaskQueue", plan.Changes[1].Diffs[1].Field)
+
+	require.NoError(t, reconciler.Apply(ctx, plan))
+	require.Equal(t, 2, client.updates)
+	require.Equal(t, 3, client.schedules["hourly"].Schedule.State.RemainingActions)
+	// Applying again is a no-op.
+	require.NoError(t, reconciler.Apply(ctx, plan))
+	require.Equal(t, 2, client.updates)
+	plan, err = reconciler.Plan(ctx, desired)
+	require.NoError(t, err)
+	require.False(t, plan.HasChanges())
+}
+
+func TestScheduleReconciler_Ownership(t *testing.T) {
+	ctx := context.Background()
+	client := &reconcilerTestScheduleClient{schedules: map[string]*ScheduleDescription{}}
+	_, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{OwnerMemoKey: "owner"})
+	require.Error(t, err)
+	_, err = NewScheduleReconciler(client, ScheduleReconcilerOptions{Prune: true})
+	require.Error(t, err)
+
+	reconciler, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{
+		Owner:        "billing",
+		OwnerMemoKey: "owner",
+		Prune:        true,
+	})
+	require.NoError(t, err)
+	other, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{})
+	require.NoError(t, err)
+	_, err = other.Reconcile(ctx, map[string]ScheduleOptions{"unowned": reconcilerTestSchedules("tq")["hourly"]})
+	require.NoError(t, err)
+
+	desired := reconcilerTestSchedules("tq")
+	plan, err := reconciler.Reconcile(ctx, desired)
+	require.NoError(t, err)
+	require.Equal(t, map[string]ScheduleChangeKind{
+		"hourly":  ScheduleChangeCreate,
+		"nightly": ScheduleChangeCreate,
+	}, reconcilerTestKinds(plan))
+	require.Equal(t, &commonpb.Memo{Fields: map[string]*commonpb.Payload{
+		"owner": client.schedules["hourly"].Memo.Fields["owner"],
+	}}, client.schedules["hourly"].Memo)
+
+	delete(desired, "nightly")
+	plan, err = reconciler.Reconcile(ctx, desired)
+	require.NoError(t, err)
+	require.Equal(t, map[string]ScheduleChangeKind{
+		"hourly":  ScheduleChangeUnchanged,
+		"nightly": ScheduleChangeDelete,
+	}, reconcilerTestKinds(plan))
+	require.NotContains(t, client.schedules, "nightly")
+	require.Contains(t, client.schedules, "unowned")
+
+	_, err = reconciler.Plan(ctx, map[string]ScheduleOptions{"unowned": desired["hourly"]})
+	require.ErrorContains(t, err, `schedule "unowned" exists but is not owned by "billing"`)
+}
+
+func TestScheduleReconciler_CreatedSincePlan(t *testing.T) {
+	ctx := context.Background()
+	client := &reconcilerTestScheduleClient{schedules: map[string]*ScheduleDescription{}}
+	reconciler, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{Owner: "billing", OwnerMemoKey: "owner"})
+	require.NoError(t, err)
+	plan, err := reconciler.Plan(ctx, reconcilerTestSchedules("tq2"))
+	require.NoError(t, err)
+	require.Equal(t, ScheduleChangeCreate, plan.Changes[0].Kind)
+
+	// Another reconciler of the same owner created the schedules with other options in the meantime.
+	stale, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{Owner: "billing", OwnerMemoKey: "owner"})
+	require.NoError(t, err)
+	_, err = stale.Reconcile(ctx, reconcilerTestSchedules("tq"))
+	require.NoError(t, err)
+	require.NoError(t, reconciler.Apply(ctx, plan))
+	require.Equal(t, 2, client.updates)
+	plan, err = reconciler.Plan(ctx, reconcilerTestSchedules("tq2"))
+	require.NoError(t, err)
+	require.False(t, plan.HasChanges())
+
+	// A schedule created by someone else is not taken over.
+	plan, err = reconciler.Plan(ctx, map[string]ScheduleOptions{"unowned": reconcilerTestSchedules("tq")["hourly"]})
+	require.NoError(t, err)
+	other, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{})
+	require.NoError(t, err)
+	_, err = other.Reconcile(ctx, map[string]ScheduleOptions{"unowned": reconcilerTestSchedules("tq2")["hourly"]})
+	require.NoError(t, err)
+	err = reconciler.Apply(ctx, plan)
+	require.ErrorContains(t, err, `schedule "unowned" exists but is not owned by "billing"`)
+}
+
+func TestScheduleReconciler_OwnerSearchAttribute(t *testing.T) {
+	ctx := context.Background()
+	client := &reconcilerTestScheduleClient{schedules: map[string]*ScheduleDescription{}}
+	owner := NewSearchAttributeKeyKeyword("ScheduleOwner")
+	reconciler, err := NewScheduleReconciler(client, ScheduleReconcilerOptions{
+		Owner:                "it's",
+		OwnerSearchAttribute: owner,
+		Query:                "TemporalSchedulePaused = false",
+		Prune:                true,
+	})
+	require.NoError(t, err)
+
+	_, err = reconciler.Reconcile(ctx, reconcilerTestSchedules("tq"))
+	require.NoError(t, err)
+	value, _ := client.schedules["nightly"].TypedSearchAttributes.GetKeyword(owner)
+	require.Equal(t, "it's", value)
+	require.Equal(t, []string{`(TemporalSchedulePaused = false) AND ScheduleOwner = 'it\'s'`}, client.queries)
+
+	plan, err := reconciler.Plan(ctx, reconcilerTestSchedules("tq"))
+	require.NoError(t, err)
+	require.False(t, plan.HasChanges())
+}
@@ -0,0 +1,554 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// minScheduleYear and maxScheduleYear bound the years a schedule spec is evaluated in, like on the server.
+	minScheduleYear = 2000
+	maxScheduleYear = 2100
+
+	// maxScheduleSkips is how many matching times in a row can be skipped before evaluation gives up.
+	maxScheduleSkips = 1_000_000
+)
+
+var (
+	scheduleMonthNames   = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	scheduleWeekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+type (
+	// ScheduleSpecEvaluator computes the times matched by a [ScheduleSpec] locally, the way the server does, to
+	// preview or validate a spec without creating a schedule. Times are nominal: the server may delay each action by a
+	// random jitter of up to [ScheduleSpec.Jitter], capped by the time until the next nominal time.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.ScheduleSpecEvaluator]
+	ScheduleSpecEvaluator struct {
+		location  *time.Location
+		calendars []*compiledScheduleCalendar
+		intervals []ScheduleIntervalSpec
+		skip      []*compiledScheduleCalendar
+		startAt   time.Time
+		endAt     time.Time
+	}
+
+	// compiledScheduleCalendar holds the matching values of each field of a ScheduleCalendarSpec as bit sets.
+	compiledScheduleCalendar struct {
+		second, minute, hour, dayOfMonth, month, dayOfWeek uint64
+		// year is empty to match all years.
+		year []ScheduleRange
+	}
+)
+
+// NewScheduleSpecEvaluator validates the spec and creates an evaluator for it. Cron expressions are parsed the way the
+// server parses them, and the time zone is loaded from the local time zone database.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.NewScheduleSpecEvaluator]
+func NewScheduleSpecEvaluator(spec ScheduleSpec) (*ScheduleSpecEvaluator, error) {
+	if spec.Jitter < 0 {
+		return nil, errors.New("schedule jitter must not be negative")
+	}
+	spec, err := expandScheduleCronExpressions(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &ScheduleSpecEvaluator{
+		location:  time.UTC,
+		intervals: spec.Intervals,
+		startAt:   spec.StartAt,
+		endAt:     spec.EndAt,
+	}
+	if spec.TimeZoneName != "" {
+		location, err := time.LoadLocation(spec.TimeZoneName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule time zone: %w", err)
+		}
+		e.location = location
+	}
+	for _, interval := range spec.Intervals {
+		if interval.Every <= 0 {
+			return nil, errors.New("schedule interval must be positive")
+		}
+		if interval.Offset < 0 || interval.Offset >= interval.Every {
+			return nil, fmt.Errorf("schedule interval offset %v must be at least 0 and less than the interval %v", interval.Offset, interval.Every)
+		}
+	}
+	for _, calendar := range spec.Calendars {
+		compiled, err := compileScheduleCalendar(calendar)
+		if err != nil {
+			return nil, err
+		}
+		e.calendars = append(e.calendars, compiled)
+	}
+	for _, calendar := range spec.Skip {
+		compiled, err := compileScheduleCalendar(calendar)
+		if err != nil {
+			return nil, fmt.Errorf("invalid skip calendar: %w", err)
+		}
+		e.skip = append(e.skip, compiled)
+	}
+	return e, nil
+}
+
+// Location returns the time zone calendar specs are matched in.
+func (e *ScheduleSpecEvaluator) Location() *time.Location {
+	return e.location
+}
+
+// Next returns the first time matched by the spec strictly after the given time, or false if there is none.
+func (e *ScheduleSpecEvaluator) Next(after time.Time) (time.Time, bool) {
+	if !e.startAt.IsZero() && after.Before(e.startAt) {
+		// StartAt is inclusive.
+		after = e.startAt.Add(-time.Nanosecond)
+	}
+	for i := 0; i < maxScheduleSkips; i++ {
+		next, ok := e.nextUnbounded(after)
+		if !ok || (!e.endAt.IsZero() && next.After(e.endAt)) {
+			return time.Time{}, false
+		}
+		if !e.isSkipped(next) {
+			return next, true
+		}
+		after = next
+	}
+	return time.Time{}, false
+}
+
+// NextTimes returns up to n times matched by the spec strictly after the given time.
+func (e *ScheduleSpecEvaluator) NextTimes(after time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		next, ok := e.Next(after)
+		if !ok {
+			break
+		}
+		times = append(times, next)
+		after = next
+	}
+	return times
+}
+
+// nextUnbounded returns the first time after the given one matched by any calendar or interval, ignoring skip
+// calendars and EndAt.
+func (e *ScheduleSpecEvaluator) nextUnbounded(after time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, calendar := range e.calendars {
+		if t, ok := calendar.next(after, e.location); ok && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	for _, interval := range e.intervals {
+		if t := nextScheduleIntervalTime(interval, after).In(e.location); next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	if next.IsZero() || next.Year() > maxScheduleYear {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (e *ScheduleSpecEvaluator) isSkipped(t time.Time) bool {
+	local := t.In(e.location)
+	for _, calendar := range e.skip {
+		if calendar.matches(local) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandScheduleCronExpressions returns the spec with its cron expressions replaced by the calendars, intervals and
+// time zone they describe, like the server stores them.
+func expandScheduleCronExpressions(spec ScheduleSpec) (ScheduleSpec, error) {
+	calendars := spec.Calendars
+	intervals := spec.Intervals
+	for _, cronString := range spec.CronExpressions {
+		calendar, interval, cronTimeZoneName, err := parseScheduleCronString(cronString)
+		if err != nil {
+			return spec, fmt.Errorf("invalid cron expression %q: %w", cronString, err)
+		}
+		if cronTimeZoneName != "" {
+			if spec.TimeZoneName != "" && spec.TimeZoneName != cronTimeZoneName {
+				return spec, fmt.Errorf("cron expression %q has time zone %q conflicting with %q", cronString, cronTimeZoneName, spec.TimeZoneName)
+			}
+			spec.TimeZoneName = cronTimeZoneName
+		}
+		if calendar != nil {
+			calendars = append(calendars[:len(calendars):len(calendars)], *calendar)
+		}
+		if interval != nil {
+			intervals = append(intervals[:len(intervals):len(intervals)], *interval)
+		}
+	}
+	spec.Calendars = calendars
+	spec.Intervals = intervals
+	spec.CronExpressions = nil
+	return spec, nil
+}
+
+func nextScheduleIntervalTime(interval ScheduleIntervalSpec, after time.Time) time.Time {
+	every := int64(interval.Every)
+	t := after.UnixNano() - int64(interval.Offset)
+	n := t / every
+	if t < 0 && t%every != 0 {
+		// Round towards negative infinity.
+		n--
+	}
+	return time.Unix(0, (n+1)*every+int64(interval.Offset))
+}
+
+func compileScheduleCalendar(calendar ScheduleCalendarSpec) (*compiledScheduleCalendar, error) {
+	applyScheduleCalendarSpecDefault(&calendar)
+	c := &compiledScheduleCalendar{year: calendar.Year}
+	var err error
+	if c.second, err = compileScheduleRanges("second", calendar.Second, 0, 59); err != nil {
+		return nil, err
+	}
+	if c.minute, err = compileScheduleRanges("minute", calendar.Minute, 0, 59); err != nil {
+		return nil, err
+	}
+	if c.hour, err = compileScheduleRanges("hour", calendar.Hour, 0, 23); err != nil {
+		return nil, err
+	}
+	if c.dayOfMonth, err = compileScheduleRanges("day of month", calendar.DayOfMonth, 1, 31); err != nil {
+		return nil, err
+	}
+	if c.month, err = compileScheduleRanges("month", calendar.Month, 1, 12); err != nil {
+		return nil, err
+	}
+	// 7 is accepted for Sunday, like in cron expressions.
+	dayOfWeek, err := compileScheduleRanges("day of week", calendar.DayOfWeek, 0, 7)
+	if err != nil {
+		return nil, err
+	}
+	c.dayOfWeek = (dayOfWeek | dayOfWeek>>7) & 0x7f
+	for _, r := range calendar.Year {
+		if _, _, err := normalizeScheduleRange("year", r, minScheduleYear, maxScheduleYear); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func normalizeScheduleRange(field string, r ScheduleRange, min, max int) (end, step int, err error) {
+	end, step = r.End, r.Step
+	if end < r.Start {
+		end = r.Start
+	}
+	if step == 0 {
+		step = 1
+	}
+	if step < 0 {
+		return 0, 0, fmt.Errorf("schedule %s range step must not be negative", field)
+	}
+	if r.Start < min || end > max {
+		return 0, 0, fmt.Errorf("schedule %s range %d-%d is out of bounds %d-%d", field, r.Start, end, min, max)
+	}
+	return end, step, nil
+}
+
+func compileScheduleRanges(field string, ranges []ScheduleRange, min, max int) (uint64, error) {
+	var bits uint64
+	for _, r := range ranges {
+		end, step, err := normalizeScheduleRange(field, r, min, max)
+		if err != nil {
+			return 0, err
+		}
+		for v := r.Start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (c *compiledScheduleCalendar) matchesYear(year int) bool {
+	if len(c.year) == 0 {
+		return true
+	}
+	for _, r := range c.year {
+		// Ranges were validated when compiling.
+		end, step, _ := normalizeScheduleRange("year", r, minScheduleYear, maxScheduleYear)
+		if year >= r.Start && year <= end && (year-r.Start)%step == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *compiledScheduleCalendar) matchesDate(year int, month time.Month, day int, weekday time.Weekday) bool {
+	return c.matchesYear(year) &&
+		c.month&(1<<int(month)) != 0 &&
+		c.dayOfMonth&(1<<day) != 0 &&
+		c.dayOfWeek&(1<<int(weekday)) != 0
+}
+
+// equal reports whether both calendars match the same clock times.
+func (c *compiledScheduleCalendar) equal(other *compiledScheduleCalendar) bool {
+	if c.second != other.second || c.minute != other.minute || c.hour != other.hour ||
+		c.dayOfMonth != other.dayOfMonth || c.month != other.month || c.dayOfWeek != other.dayOfWeek {
+		return false
+	}
+	for year := minScheduleYear; year <= maxScheduleYear; year++ {
+		if c.matchesYear(year) != other.matchesYear(year) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether the clock time of t matches the calendar.
+func (c *compiledScheduleCalendar) matches(t time.Time) bool {
+	return c.matchesDate(t.Year(), t.Month(), t.Day(), t.Weekday()) &&
+		c.hour&(1<<t.Hour()) != 0 &&
+		c.minute&(1<<t.Minute()) != 0 &&
+		c.second&(1<<t.Second()) != 0
+}
+
+// next returns the first time strictly after the given one whose clock time in the location matches the calendar.
+// Clock times are matched literally: one skipped by a daylight saving time change never matches and one repeated by
+// it matches twice.
+func (c *compiledScheduleCalendar) next(after time.Time, location *time.Location) (time.Time, bool) {
+	// Within a span of time with a fixed zone offset, clock times increase with times, so search each span in turn.
+	local := after.In(location)
+	afterClock := scheduleClockTime(local)
+	for local.Year() <= maxScheduleYear {
+		_, offset := local.Zone()
+		_, zoneEnd := local.ZoneBounds()
+		var until time.Time
+		if !zoneEnd.IsZero() {
+			until = zoneEnd.UTC().Add(time.Duration(offset) * time.Second)
+		}
+		if clock, ok := c.nextClock(afterClock, until); ok {
+			return clock.Add(-time.Duration(offset) * time.Second).In(location), true
+		}
+		if zoneEnd.IsZero() {
+			break
+		}
+		// The next span starts at zoneEnd, which is itself a candidate.
+		local = zoneEnd.In(location)
+		afterClock = scheduleClockTime(local).Add(-time.Nanosecond)
+	}
+	return time.Time{}, false
+}
+
+// nextClock returns the first clock time strictly after the given one and before until, unless until is zero, that
+// matches the calendar. Clock times are handled as UTC times to do arithmetic on them.
+func (c *compiledScheduleCalendar) nextClock(after, until time.Time) (time.Time, bool) {
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, time.UTC)
+	for day.Year() <= maxScheduleYear && (until.IsZero() || day.Before(until)) {
+		if !c.matchesYear(day.Year()) {
+			day = time.Date(day.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.month&(1<<int(day.Month())) == 0 {
+			day = time.Date(day.Year(), day.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchesDate(day.Year(), day.Month(), day.Day(), day.Weekday()) {
+			day = day.AddDate(0, 0, 1)
+			continue
+		}
+		for hour := 0; hour < 24; hour++ {
+			if c.hour&(1<<hour) == 0 || day.Add(time.Duration(hour+1)*time.Hour).Before(after) {
+				continue
+			}
+			for minute := 0; minute < 60; minute++ {
+				minuteStart := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+				if c.minute&(1<<minute) == 0 || minuteStart.Add(time.Minute).Before(after) {
+					continue
+				}
+				for second := 0; second < 60; second++ {
+					clock := minuteStart.Add(time.Duration(second) * time.Second)
+					if c.second&(1<<second) == 0 || !clock.After(after) {
+						continue
+					}
+					if !until.IsZero() && !clock.Before(until) {
+						return time.Time{}, false
+					}
+					return clock, true
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, false
+}
+
+// scheduleClockTime returns the clock time of t as a UTC time.
+func scheduleClockTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// parseScheduleCronString parses a cron expression into a calendar or an interval and the time zone it sets, as
+// described on [ScheduleSpec.CronExpressions].
+func parseScheduleCronString(s string) (*ScheduleCalendarSpec, *ScheduleIntervalSpec, string, error) {
+	s = strings.TrimSpace(s)
+	var comment string
+	if i := strings.Index(s, "#"); i >= 0 {
+		comment = strings.TrimSpace(s[i+1:])
+		s = strings.TrimSpace(s[:i])
+	}
+	var timeZoneName string
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if strings.HasPrefix(s, prefix) {
+			fields := strings.SplitN(strings.TrimPrefix(s, prefix), " ", 2)
+			timeZoneName = fields[0]
+			s = ""
+			if len(fields) == 2 {
+				s = strings.TrimSpace(fields[1])
+			}
+			break
+		}
+	}
+
+	if strings.HasPrefix(s, "@every ") {
+		interval, err := parseScheduleCronInterval(strings.TrimSpace(strings.TrimPrefix(s, "@every ")))
+		return nil, interval, timeZoneName, err
+	}
+	switch s {
+	case "@yearly", "@annually":
+		s = "0 0 1 1 *"
+	case "@monthly":
+		s = "0 0 1 * *"
+	case "@weekly":
+		s = "0 0 * * 0"
+	case "@daily", "@midnight":
+		s = "0 0 * * *"
+	case "@hourly":
+		s = "0 * * * *"
+	}
+
+	fields := strings.Fields(s)
+	second := "0"
+	year := "*"
+	switch len(fields) {
+	case 5:
+	case 6:
+		year = fields[5]
+	case 7:
+		second, year = fields[0], fields[6]
+		fields = fields[1:6]
+	default:
+		return nil, nil, "", fmt.Errorf("expected 5 to 7 fields but got %d", len(fields))
+	}
+
+	calendar := &ScheduleCalendarSpec{Comment: comment}
+	var err error
+	if calendar.Second, err = parseScheduleCronField(second, 0, 59, nil); err != nil {
+		return nil, nil, "", fmt.Errorf("second: %w", err)
+	}
+	if calendar.Minute, err = parseScheduleCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, nil, "", fmt.Errorf("minute: %w", err)
+	}
+	if calendar.Hour, err = parseScheduleCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, nil, "", fmt.Errorf("hour: %w", err)
+	}
+	if calendar.DayOfMonth, err = parseScheduleCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, nil, "", fmt.Errorf("day of month: %w", err)
+	}
+	if calendar.Month, err = parseScheduleCronField(fields[3], 1, 12, scheduleMonthNames); err != nil {
+		return nil, nil, "", fmt.Errorf("month: %w", err)
+	}
+	if calendar.DayOfWeek, err = parseScheduleCronField(fields[4], 0, 7, scheduleWeekdayNames); err != nil {
+		return nil, nil, "", fmt.Errorf("day of week: %w", err)
+	}
+	if year != "*" {
+		if calendar.Year, err = parseScheduleCronField(year, minScheduleYear, maxScheduleYear, nil); err != nil {
+			return nil, nil, "", fmt.Errorf("year: %w", err)
+		}
+	}
+	return calendar, nil, timeZoneName, nil
+}
+
+// parseScheduleCronField parses a comma separated list of values, ranges and steps, where names, if given, are the
+// names of the values from min.
+func parseScheduleCronField(s string, min, max int, names []string) ([]ScheduleRange, error) {
+	var ranges []ScheduleRange
+	for _, part := range strings.Split(s, ",") {
+		base, stepString, hasStep := strings.Cut(part, "/")
+		r := ScheduleRange{Start: min, End: max}
+		if base != "*" && base != "?" {
+			startString, endString, isRange := strings.Cut(base, "-")
+			var err error
+			if r.Start, err = parseScheduleCronValue(startString, min, names); err != nil {
+				return nil, err
+			}
+			switch {
+			case isRange:
+				if r.End, err = parseScheduleCronValue(endString, min, names); err != nil {
+					return nil, err
+				}
+			case !hasStep:
+				r.End = r.Start
+			}
+		}
+		if hasStep {
+			step, err := strconv.Atoi(stepString)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepString)
+			}
+			r.Step = step
+		}
+		if r.Start < min || r.End > max || r.End < r.Start {
+			return nil, fmt.Errorf("range %q is out of bounds %d-%d", part, min, max)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+func parseScheduleCronValue(s string, min int, names []string) (int, error) {
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, nil
+	}
+	if len(s) >= 3 {
+		for i, name := range names {
+			if strings.HasPrefix(strings.ToLower(s), name) {
+				return min + i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid value %q", s)
+}
+
+// parseScheduleCronInterval parses the <interval>[/<phase>] of an @every expression.
+func parseScheduleCronInterval(s string) (*ScheduleIntervalSpec, error) {
+	everyString, offsetString, hasOffset := strings.Cut(s, "/")
+	every, err := parseScheduleCronDuration(everyString)
+	if err != nil {
+		return nil, err
+	}
+	interval := &ScheduleIntervalSpec{Every: every}
+	if hasOffset {
+		if interval.Offset, err = parseScheduleCronDuration(offsetString); err != nil {
+			return nil, err
+		}
+	}
+	return interval, nil
+}
+
+func parseScheduleCronDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
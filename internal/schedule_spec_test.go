@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustScheduleSpecEvaluator(t *testing.T, spec ScheduleSpec) *ScheduleSpecEvaluator {
+	e, err := NewScheduleSpecEvaluator(spec)
+	require.NoError(t, err)
+	return e
+}
+
+func TestScheduleSpecEvaluator_Calendars(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	structured := mustScheduleSpecEvaluator(t, ScheduleSpec{
+		Calendars: []ScheduleCalendarSpec{{
+			Hour:      []ScheduleRange{{Start: 12}},
+			DayOfWeek: []ScheduleRange{{Start: 1, End: 3}, {Start: 5}},
+		}},
+	})
+	cron := mustScheduleSpecEvaluator(t, ScheduleSpec{CronExpressions: []string{"0 12 * * MON-WED,FRI # noon"}})
+	expected := []time.Time{
+		time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 2, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 3, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 5, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 8, 12, 0, 0, 0, time.UTC),
+	}
+	require.Equal(t, expected, structured.NextTimes(start, 5))
+	require.Equal(t, expected, cron.NextTimes(start, 5))
+
+	// Times are strictly after the given time.
+	next, ok := cron.Next(expected[0])
+	require.True(t, ok)
+	require.Equal(t, expected[1], next)
+
+	// February 30 never matches.
+	never := mustScheduleSpecEvaluator(t, ScheduleSpec{CronExpressions: []string{"0 0 30 2 *"}})
+	_, ok = never.Next(start)
+	require.False(t, ok)
+}
+
+func TestScheduleSpecEvaluator_IntervalsAndBounds(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	e := mustScheduleSpecEvaluator(t, ScheduleSpec{
+		Intervals:       []ScheduleIntervalSpec{{Every: 6 * time.Hour, Offset: 30 * time.Minute}},
+		CronExpressions: []string{"@every 1d/1h"},
+		Skip:            []ScheduleCalendarSpec{{Hour: []ScheduleRange{{Start: 12}}, Minute: []ScheduleRange{{Start: 30}}}},
+		StartAt:         time.Date(2024, time.January, 1, 6, 30, 0, 0, time.UTC),
+		EndAt:           time.Date(2024, time.January, 2, 6, 30, 0, 0, time.UTC),
+	})
+	require.Equal(t, []time.Time{
+		time.Date(2024, time.January, 1, 6, 30, 0, 0, time.UTC),
+		time.Date(2024, time.January, 1, 18, 30, 0, 0, time.UTC),
+		time.Date(2024, time.January, 2, 0, 30, 0, 0, time.UTC),
+		time.Date(2024, time.January, 2, 1, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 2, 6, 30, 0, 0, time.UTC),
+	}, e.NextTimes(start, 10))
+}
+
+func TestScheduleSpecEvaluator_DaylightSavingTime(t *testing.T) {
+	t.Parallel()
+	e := mustScheduleSpecEvaluator(t, ScheduleSpec{
+		CronExpressions: []string{"30 1,2 * * *"},
+		TimeZoneName:    "America/New_York",
+	})
+	newYork := e.Location()
+
+	// 2:30 doesn't exist on the day clocks spring forward.
+	require.Equal(t, []time.Time{
+		time.Date(2024, time.March, 9, 2, 30, 0, 0, newYork),
+		time.Date(2024, time.March, 10, 1, 30, 0, 0, newYork),
+		time.Date(2024, time.March, 11, 1, 30, 0, 0, newYork),
+	}, e.NextTimes(time.Date(2024, time.March, 9, 2, 0, 0, 0, newYork), 3))
+
+	// 1:30 happens twice on the day clocks fall back.
+	require.Equal(t, []time.Time{
+		time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC),
+		time.Date(2024, time.November, 3, 6, 30, 0, 0, time.UTC),
+		time.Date(2024, time.November, 3, 7, 30, 0, 0, time.UTC),
+	}, utcTimes(e.NextTimes(time.Date(2024, time.November, 3, 0, 0, 0, 0, newYork), 3)))
+}
+
+func utcTimes(times []time.Time) []time.Time {
+	for i, t := range times {
+		times[i] = t.UTC()
+	}
+	return times
+}
+
+func TestScheduleSpecEvaluator_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, spec := range []ScheduleSpec{
+		{CronExpressions: []string{"0 12 * *"}},
+		{CronExpressions: []string{"0 25 * * *"}},
+		{CronExpressions: []string{"0 12 * * FUNDAY"}},
+		{CronExpressions: []string{"CRON_TZ=Europe/Paris 0 12 * * *"}, TimeZoneName: "UTC"},
+		{Calendars: []ScheduleCalendarSpec{{Month: []ScheduleRange{{Start: 13}}}}},
+		{Intervals: []ScheduleIntervalSpec{{Every: time.Hour, Offset: time.Hour}}},
+		{TimeZoneName: "Nowhere/Nothing"},
+		{Jitter: -time.Second},
+	} {
+		_, err := NewScheduleSpecEvaluator(spec)
+		require.Error(t, err, "%+v", spec)
+	}
+}
@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TrafficShapingMode controls what happens to a call that exceeds the limits of
+// its traffic class.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/client.TrafficShapingMode]
+type TrafficShapingMode int
+
+const (
+	// TrafficShapingModeBlock makes calls exceeding the limits wait until they
+	// are within them or their context is done. This is the default.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.TrafficShapingModeBlock]
+	TrafficShapingModeBlock TrafficShapingMode = iota
+
+	// TrafficShapingModeFailFast makes calls exceeding the limits fail
+	// immediately with a [go.temporal.io/api/serviceerror.ResourceExhausted]
+	// error without being sent to the server.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.TrafficShapingModeFailFast]
+	TrafficShapingModeFailFast
+)
+
+type (
+	// TrafficShapingOptions are options for limiting the rate and concurrency of
+	// client calls by class, so that, for example, a batch job sharing a client
+	// can't starve latency-sensitive callers.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.TrafficShapingOptions]
+	TrafficShapingOptions struct {
+		// Classes of calls and their limits. A call belongs to the first class
+		// listing its method, and calls in no class are not limited.
+		Classes []TrafficClass
+	}
+
+	// TrafficClass is a set of gRPC methods that share rate and concurrency
+	// limits. Time spent waiting for the limits is recorded in the
+	// temporal_request_shaping_delay metric, calls rejected by them in the
+	// temporal_request_shaping_rejected metric, and the calls in flight in the
+	// temporal_request_shaping_in_flight metric, all tagged with the class name.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.TrafficClass]
+	TrafficClass struct {
+		// Name of the class, used in the traffic_class metric tag. Required.
+		Name string
+
+		// Methods are the names of the WorkflowService gRPC methods in this
+		// class, e.g. "SignalWorkflowExecution" or "ListWorkflowExecutions". These
+		// are the names in the operation metric tag. Required.
+		Methods []string
+
+		// RequestsPerSecond is the maximum rate of calls across the class.
+		//
+		// default: unlimited
+		RequestsPerSecond float64
+
+		// Burst is the maximum number of calls that can be made at once above
+		// RequestsPerSecond. Only used if RequestsPerSecond is set.
+		//
+		// default: RequestsPerSecond rounded up
+		Burst int
+
+		// MaxConcurrent is the maximum number of calls of the class in flight.
+		//
+		// default: unlimited
+		MaxConcurrent int
+
+		// Mode controls what happens to calls exceeding the limits.
+		//
+		// default: TrafficShapingModeBlock
+		Mode TrafficShapingMode
+	}
+)
+
+type trafficClassLimiter struct {
+	name    string
+	mode    TrafficShapingMode
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+func (o *TrafficShapingOptions) validate() error {
+	var classNames []string
+	for _, class := range o.Classes {
+		if class.Name == "" {
+			return fmt.Errorf("traffic class must have a name")
+		} else if slices.Contains(classNames, class.Name) {
+			return fmt.Errorf("duplicate traffic class %q", class.Name)
+		} else if len(class.Methods) == 0 {
+			return fmt.Errorf("traffic class %q must have methods", class.Name)
+		} else if class.RequestsPerSecond < 0 || class.Burst < 0 || class.MaxConcurrent < 0 {
+			return fmt.Errorf("traffic class %q limits cannot be negative", class.Name)
+		}
+		classNames = append(classNames, class.Name)
+	}
+	return nil
+}
+
+// newTrafficShapingInterceptor creates an interceptor enforcing the limits of
+// the given options, which must have been validated.
+func newTrafficShapingInterceptor(options TrafficShapingOptions, metricsHandler metrics.Handler) grpc.UnaryClientInterceptor {
+	limitersByMethod := map[string]*trafficClassLimiter{}
+	for _, class := range options.Classes {
+		limiter := &trafficClassLimiter{name: class.Name, mode: class.Mode}
+		if class.RequestsPerSecond > 0 {
+			burst := class.Burst
+			if burst == 0 {
+				burst = int(math.Ceil(class.RequestsPerSecond))
+			}
+			limiter.limiter = rate.NewLimiter(rate.Limit(class.RequestsPerSecond), burst)
+		}
+		if class.MaxConcurrent > 0 {
+			limiter.slots = make(chan struct{}, class.MaxConcurrent)
+		}
+		for _, method := range class.Methods {
+			// First class wins
+			if _, ok := limitersByMethod[method]; !ok {
+				limitersByMethod[method] = limiter
+			}
+		}
+	}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		limiter := limitersByMethod[method[strings.LastIndex(method, "/")+1:]]
+		if limiter == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		// Metrics are per class rather than per operation
+		handler, _ := ctx.Value(metrics.HandlerContextKey{}).(metrics.Handler)
+		if handler == nil {
+			handler = metricsHandler
+		}
+		handler = handler.WithTags(metrics.TrafficClassTags(limiter.name))
+		release, err := limiter.acquire(ctx, handler)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// acquire waits for, or in fail-fast mode checks, a concurrency slot and then
+// a rate limit token. The returned function releases the slot.
+func (l *trafficClassLimiter) acquire(ctx context.Context, handler metrics.Handler) (func(), error) {
+	start := time.Now()
+	if l.slots != nil {
+		if l.mode == TrafficShapingModeFailFast {
+			select {
+			case l.slots <- struct{}{}:
+			default:
+				return nil, l.reject(handler, "concurrency")
+			}
+		} else {
+			select {
+			case l.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+	}
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+			handler.Gauge(metrics.TemporalRequestShapingInFlight).Update(float64(len(l.slots)))
+		}
+	}
+	if l.limiter != nil {
+		if l.mode == TrafficShapingModeFailFast {
+			if !l.limiter.Allow() {
+				release()
+				return nil, l.reject(handler, "rate")
+			}
+		} else if err := l.limiter.Wait(ctx); err != nil {
+			release()
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, status.FromContextError(ctxErr).Err()
+			}
+			// The wait would exceed the context deadline
+			return nil, status.Error(codes.DeadlineExceeded, fmt.Sprintf("traffic class %q: %v", l.name, err))
+		}
+	}
+	if l.mode == TrafficShapingModeBlock {
+		handler.Timer(metrics.TemporalRequestShapingDelay).Record(time.Since(start))
+	}
+	if l.slots != nil {
+		handler.Gauge(metrics.TemporalRequestShapingInFlight).Update(float64(len(l.slots)))
+	}
+	return release, nil
+}
+
+func (l *trafficClassLimiter) reject(handler metrics.Handler, limit string) error {
+	handler.Counter(metrics.TemporalRequestShapingRejected).Inc(1)
+	return status.Error(codes.ResourceExhausted, fmt.Sprintf("traffic class %q %s limit exceeded", l.name, limit))
+}
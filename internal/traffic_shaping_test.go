@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/internal/common/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTrafficShapingConcurrency(t *testing.T) {
+	metricsHandler := metrics.NewCapturingHandler()
+	interceptor := newTrafficShapingInterceptor(TrafficShapingOptions{Classes: []TrafficClass{
+		{Name: "list", Methods: []string{"ListWorkflowExecutions"}, MaxConcurrent: 1},
+		{Name: "list-fail-fast", Methods: []string{"ListWorkflowExecutions", "CountWorkflowExecutions"}, MaxConcurrent: 1, Mode: TrafficShapingModeFailFast},
+	}}, metricsHandler)
+	release := make(chan struct{})
+	blockingInvoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		<-release
+		return nil
+	}
+	call := func(ctx context.Context, method string) chan error {
+		errCh := make(chan error, 1)
+		go func() { errCh <- interceptor(ctx, method, nil, nil, nil, blockingInvoker) }()
+		return errCh
+	}
+
+	// Blocking class makes the second call wait for the first, and the first
+	// class listing a method wins
+	first := call(context.Background(), workflowservice.WorkflowService_ListWorkflowExecutions_FullMethodName)
+	require.Eventually(t, func() bool { return len(metricsHandler.Gauges()) > 0 }, time.Second, time.Millisecond)
+	second := call(context.Background(), workflowservice.WorkflowService_ListWorkflowExecutions_FullMethodName)
+	select {
+	case <-second:
+		require.Fail(t, "second call should be blocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	// Waiting calls respect their context
+	ctx, cancel := context.WithCancel(context.Background())
+	third := call(ctx, workflowservice.WorkflowService_ListWorkflowExecutions_FullMethodName)
+	cancel()
+	require.Equal(t, codes.Canceled, status.Code(<-third))
+	release <- struct{}{}
+	require.NoError(t, <-first)
+	release <- struct{}{}
+	require.NoError(t, <-second)
+
+	// Fail-fast class rejects calls over the limit
+	first = call(context.Background(), workflowservice.WorkflowService_CountWorkflowExecutions_FullMethodName)
+	require.Eventually(t, func() bool {
+		for _, gauge := range metricsHandler.Gauges() {
+			if gauge.Tags[metrics.TrafficClassTagName] == "list-fail-fast" {
+				return gauge.Value() == 1
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond)
+	err := <-call(context.Background(), workflowservice.WorkflowService_CountWorkflowExecutions_FullMethodName)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Contains(t, err.Error(), `traffic class "list-fail-fast" concurrency limit exceeded`)
+	release <- struct{}{}
+	require.NoError(t, <-first)
+
+	// Unclassified methods aren't limited
+	close(release)
+	require.NoError(t, <-call(context.Background(), workflowservice.WorkflowService_SignalWorkflowExecution_FullMethodName))
+
+	counters := map[string]int64{}
+	for _, counter := range metricsHandler.Counters() {
+		counters[counter.Name+" "+counter.Tags[metrics.TrafficClassTagName]] += counter.Value()
+	}
+	require.Equal(t, map[string]int64{metrics.TemporalRequestShapingRejected + " list-fail-fast": 1}, counters)
+	var delays int64
+	for _, timer := range metricsHandler.Timers() {
+		if timer.Name == metrics.TemporalRequestShapingDelay {
+			require.Equal(t, "list", timer.Tags[metrics.TrafficClassTagName])
+			delays += timer.Count()
+		}
+	}
+	require.Equal(t, int64(2), delays)
+}
+
+func TestTrafficShapingRate(t *testing.T) {
+	interceptor := newTrafficShapingInterceptor(TrafficShapingOptions{Classes: []TrafficClass{
+		{Name: "signal", Methods: []string{"SignalWorkflowExecution"}, RequestsPerSecond: 20, Burst: 1},
+		{Name: "query", Methods: []string{"QueryWorkflow"}, RequestsPerSecond: 0.001, Mode: TrafficShapingModeFailFast},
+	}}, metrics.NopHandler)
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return nil
+	}
+
+	// Blocking class spaces out calls
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, interceptor(context.Background(),
+			workflowservice.WorkflowService_SignalWorkflowExecution_FullMethodName, nil, nil, nil, invoker))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// Waits that would exceed the deadline fail right away
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err := interceptor(ctx, workflowservice.WorkflowService_SignalWorkflowExecution_FullMethodName, nil, nil, nil, invoker)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// Fail-fast class rejects calls over the rate
+	require.NoError(t, interceptor(context.Background(),
+		workflowservice.WorkflowService_QueryWorkflow_FullMethodName, nil, nil, nil, invoker))
+	err = interceptor(context.Background(), workflowservice.WorkflowService_QueryWorkflow_FullMethodName, nil, nil, nil, invoker)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Contains(t, err.Error(), `traffic class "query" rate limit exceeded`)
+}
+
+func TestTrafficShapingValidation(t *testing.T) {
+	for _, tc := range []struct {
+		classes []TrafficClass
+		err     string
+	}{
+		{[]TrafficClass{{Methods: []string{"QueryWorkflow"}}}, "traffic class must have a name"},
+		{[]TrafficClass{{Name: "a", Methods: []string{"QueryWorkflow"}}, {Name: "a", Methods: []string{"QueryWorkflow"}}}, `duplicate traffic class "a"`},
+		{[]TrafficClass{{Name: "a"}}, `traffic class "a" must have methods`},
+		{[]TrafficClass{{Name: "a", Methods: []string{"QueryWorkflow"}, MaxConcurrent: -1}}, `traffic class "a" limits cannot be negative`},
+	} {
+		_, err := NewClient(context.Background(), ClientOptions{TrafficShaping: TrafficShapingOptions{Classes: tc.classes}})
+		require.EqualError(t, err, tc.err)
+	}
+}
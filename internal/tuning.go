@@ -493,6 +493,18 @@ func (t *trackingSlotSupplier) ReleaseSlot(permit *SlotPermit, reason SlotReleas
 	t.publishMetrics(usedSlots)
 }
 
+func (t *trackingSlotSupplier) usedSlotCount() int {
+	t.slotsMutex.Lock()
+	defer t.slotsMutex.Unlock()
+	return len(t.usedSlots)
+}
+
+// issuedSlotCount returns the number of reserved slots, including those held by
+// open polls or by tasks that haven't started processing yet.
+func (t *trackingSlotSupplier) issuedSlotCount() int {
+	return int(t.issuedSlotsAtomic.Load())
+}
+
 func (t *trackingSlotSupplier) publishMetrics(usedSlots int) {
 	if t.inner.MaxSlots() != 0 {
 		t.taskSlotsAvailableGauge.Update(float64(t.inner.MaxSlots() - usedSlots))
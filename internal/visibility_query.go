@@ -0,0 +1,288 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// VisibilityQuery is a filter of a visibility query built from typed search attribute keys. Create one with the
+	// methods of the keys, such as [SearchAttributeKeyKeyword.Equal], and combine them with [VisibilityQuery.And] and
+	// [VisibilityQuery.Or]. Set it as the TypedQuery of [ClientListWorkflowExecutionsOptions],
+	// [ClientListActivitiesOptions], [ClientCountActivitiesOptions] or [ScheduleListOptions]. APIs only taking a query
+	// string, like ListWorkflow and CountWorkflow, take the rendering of [VisibilityQuery.String], which escapes the
+	// values and names. The zero value matches everything.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/client.VisibilityQuery]
+	VisibilityQuery struct {
+		filter   string
+		operator visibilityQueryOperator
+		orderBy  []string
+	}
+
+	visibilityQueryOperator int
+)
+
+const (
+	visibilityQueryCondition visibilityQueryOperator = iota
+	visibilityQueryAnd
+	visibilityQueryOr
+)
+
+// visibilityQueryIdentifier matches the search attribute names that don't need to be quoted.
+var visibilityQueryIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// And returns a query matching the executions matched by this query and all the given ones. Order by clauses of all the
+// queries are kept, in order.
+func (q VisibilityQuery) And(queries ...VisibilityQuery) VisibilityQuery {
+	return q.combine(visibilityQueryAnd, queries)
+}
+
+// Or returns a query matching the executions matched by this query or any of the given ones. Order by clauses of all
+// the queries are kept, in order.
+func (q VisibilityQuery) Or(queries ...VisibilityQuery) VisibilityQuery {
+	return q.combine(visibilityQueryOr, queries)
+}
+
+// OrderBy returns a query sorting the executions by the given search attribute, in ascending order unless descending
+// is set. Sorting is not supported by CountWorkflow and depends on the visibility store of the server.
+func (q VisibilityQuery) OrderBy(key SearchAttributeKey, descending bool) VisibilityQuery {
+	order := visibilityQueryName(key) + " ASC"
+	if descending {
+		order = visibilityQueryName(key) + " DESC"
+	}
+	q.orderBy = append(slices.Clip(q.orderBy), order)
+	return q
+}
+
+// String renders the query for the Query field of the visibility APIs.
+func (q VisibilityQuery) String() string {
+	if len(q.orderBy) == 0 {
+		return q.filter
+	}
+	orderBy := "ORDER BY " + strings.Join(q.orderBy, ", ")
+	if q.filter == "" {
+		return orderBy
+	}
+	return q.filter + " " + orderBy
+}
+
+func (q VisibilityQuery) combine(operator visibilityQueryOperator, queries []VisibilityQuery) VisibilityQuery {
+	var filtered []VisibilityQuery
+	var combined VisibilityQuery
+	for _, query := range append([]VisibilityQuery{q}, queries...) {
+		combined.orderBy = append(combined.orderBy, query.orderBy...)
+		if query.filter != "" {
+			filtered = append(filtered, query)
+		}
+	}
+	if len(filtered) == 1 {
+		combined.filter = filtered[0].filter
+		combined.operator = filtered[0].operator
+	} else if len(filtered) > 1 {
+		filters := make([]string, len(filtered))
+		for i, query := range filtered {
+			filters[i] = query.filter
+			if query.operator != visibilityQueryCondition && query.operator != operator {
+				filters[i] = "(" + query.filter + ")"
+			}
+		}
+		separator := " AND "
+		if operator == visibilityQueryOr {
+			separator = " OR "
+		}
+		combined.filter = strings.Join(filters, separator)
+		combined.operator = operator
+	}
+	return combined
+}
+
+func newVisibilityQueryCondition(key SearchAttributeKey, format string, args ...interface{}) VisibilityQuery {
+	return VisibilityQuery{filter: visibilityQueryName(key) + " " + fmt.Sprintf(format, args...)}
+}
+
+func newVisibilityQueryIn(key SearchAttributeKey, values []string) VisibilityQuery {
+	if len(values) == 0 {
+		// An empty IN list is a syntax error, render a condition that never matches instead.
+		name := visibilityQueryName(key)
+		return VisibilityQuery{filter: name + " IS NULL AND " + name + " IS NOT NULL", operator: visibilityQueryAnd}
+	}
+	return newVisibilityQueryCondition(key, "IN (%s)", strings.Join(values, ", "))
+}
+
+// visibilityQueryOrString returns the query string of the visibility APIs taking both a query string and a typed query.
+func visibilityQueryOrString(query string, typed VisibilityQuery) (string, error) {
+	rendered := typed.String()
+	if rendered == "" {
+		return query, nil
+	} else if query != "" {
+		return "", errors.New("only one of Query and TypedQuery can be set")
+	}
+	return rendered, nil
+}
+
+// visibilityQueryName renders the name of a search attribute, quoted with backticks when it isn't a plain identifier.
+func visibilityQueryName(key SearchAttributeKey) string {
+	name := key.GetName()
+	if visibilityQueryIdentifier.MatchString(name) {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+var visibilityQueryStringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func visibilityQueryString(value string) string {
+	return "'" + visibilityQueryStringEscaper.Replace(value) + "'"
+}
+
+func visibilityQueryInt64(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func visibilityQueryFloat64(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func visibilityQueryTime(value time.Time) string {
+	return visibilityQueryString(value.UTC().Format(time.RFC3339Nano))
+}
+
+func visibilityQueryValues[T any](values []T, render func(T) string) []string {
+	rendered := make([]string, len(values))
+	for i, value := range values {
+		rendered[i] = render(value)
+	}
+	return rendered
+}
+
+// IsNull returns a query matching the executions that don't have a value for the attribute.
+//
+// NOTE: Experimental
+func (bk baseSearchAttributeKey) IsNull() VisibilityQuery {
+	return newVisibilityQueryCondition(bk, "IS NULL")
+}
+
+// Equal returns a query matching the executions whose text attribute contains the words of the given value.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyString) Equal(value string) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "= %s", visibilityQueryString(value))
+}
+
+// Equal returns a query matching the executions whose attribute is the given value.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyKeyword) Equal(value string) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "= %s", visibilityQueryString(value))
+}
+
+// In returns a query matching the executions whose attribute is one of the given values. It matches no execution
+// if no value is given.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyKeyword) In(values ...string) VisibilityQuery {
+	return newVisibilityQueryIn(k, visibilityQueryValues(values, visibilityQueryString))
+}
+
+// StartsWith returns a query matching the executions whose attribute starts with the given prefix.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyKeyword) StartsWith(prefix string) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "STARTS_WITH %s", visibilityQueryString(prefix))
+}
+
+// Equal returns a query matching the executions whose attribute is the given value.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyBool) Equal(value bool) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "= %t", value)
+}
+
+// Equal returns a query matching the executions whose attribute is the given value.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyInt64) Equal(value int64) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "= %s", visibilityQueryInt64(value))
+}
+
+// In returns a query matching the executions whose attribute is one of the given values. It matches no execution
+// if no value is given.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyInt64) In(values ...int64) VisibilityQuery {
+	return newVisibilityQueryIn(k, visibilityQueryValues(values, visibilityQueryInt64))
+}
+
+// Between returns a query matching the executions whose attribute is between from and to, inclusive.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyInt64) Between(from, to int64) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "BETWEEN %s AND %s", visibilityQueryInt64(from), visibilityQueryInt64(to))
+}
+
+// Equal returns a query matching the executions whose attribute is the given value.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyFloat64) Equal(value float64) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "= %s", visibilityQueryFloat64(value))
+}
+
+// In returns a query matching the executions whose attribute is one of the given values. It matches no execution
+// if no value is given.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyFloat64) In(values ...float64) VisibilityQuery {
+	return newVisibilityQueryIn(k, visibilityQueryValues(values, visibilityQueryFloat64))
+}
+
+// Between returns a query matching the executions whose attribute is between from and to, inclusive.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyFloat64) Between(from, to float64) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "BETWEEN %s AND %s", visibilityQueryFloat64(from), visibilityQueryFloat64(to))
+}
+
+// Equal returns a query matching the executions whose attribute is the given time.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyTime) Equal(value time.Time) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "= %s", visibilityQueryTime(value))
+}
+
+// In returns a query matching the executions whose attribute is one of the given times. It matches no execution
+// if no value is given.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyTime) In(values ...time.Time) VisibilityQuery {
+	return newVisibilityQueryIn(k, visibilityQueryValues(values, visibilityQueryTime))
+}
+
+// Between returns a query matching the executions whose attribute is between from and to, inclusive.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyTime) Between(from, to time.Time) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "BETWEEN %s AND %s", visibilityQueryTime(from), visibilityQueryTime(to))
+}
+
+// Equal returns a query matching the executions whose attribute contains the given value.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyKeywordList) Equal(value string) VisibilityQuery {
+	return newVisibilityQueryCondition(k, "= %s", visibilityQueryString(value))
+}
+
+// In returns a query matching the executions whose attribute contains one of the given values. It matches no
+// execution if no value is given.
+//
+// NOTE: Experimental
+func (k SearchAttributeKeyKeywordList) In(values ...string) VisibilityQuery {
+	return newVisibilityQueryIn(k, visibilityQueryValues(values, visibilityQueryString))
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibilityQuery(t *testing.T) {
+	t.Parallel()
+	workflowType := NewSearchAttributeKeyKeyword("WorkflowType")
+	status := NewSearchAttributeKeyKeyword("ExecutionStatus")
+	closeTime := NewSearchAttributeKeyTime("CloseTime")
+	startTime := NewSearchAttributeKeyTime("StartTime")
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("", 3600))
+
+	tests := []struct {
+		name     string
+		query    VisibilityQuery
+		expected string
+	}{
+		{"empty", VisibilityQuery{}, ""},
+		{"keyword", workflowType.Equal("order"), "WorkflowType = 'order'"},
+		{"escaped", workflowType.Equal(`it's \ done' OR 1=1`), `WorkflowType = 'it\'s \\ done\' OR 1=1'`},
+		{"quoted name", NewSearchAttributeKeyKeyword("Team`Name").Equal("a"), "`Team``Name` = 'a'"},
+		{"text", NewSearchAttributeKeyString("Summary").Equal("hello world"), "Summary = 'hello world'"},
+		{"bool", NewSearchAttributeKeyBool("Urgent").Equal(true), "Urgent = true"},
+		{"int", NewSearchAttributeKeyInt64("Attempt").In(1, 2, 3), "Attempt IN (1, 2, 3)"},
+		{"float", NewSearchAttributeKeyFloat64("Score").Between(0.5, 1e21), "Score BETWEEN 0.5 AND 1000000000000000000000"},
+		{"time", closeTime.Between(from, to), "CloseTime BETWEEN '2024-01-01T00:00:00Z' AND '2024-01-02T02:04:05.000000006Z'"},
+		{"keyword list", NewSearchAttributeKeyKeywordList("Tags").In("a", "b"), "Tags IN ('a', 'b')"},
+		{"starts with", workflowType.StartsWith("order-"), "WorkflowType STARTS_WITH 'order-'"},
+		{"null", closeTime.IsNull(), "CloseTime IS NULL"},
+		{
+			"and or",
+			workflowType.Equal("a").And(status.In("Running", "Failed").Or(closeTime.IsNull()), startTime.Equal(from)),
+			"WorkflowType = 'a' AND (ExecutionStatus IN ('Running', 'Failed') OR CloseTime IS NULL) AND StartTime = '2024-01-01T00:00:00Z'",
+		},
+		{
+			"nested same operator",
+			workflowType.Equal("a").Or(workflowType.Equal("b").Or(workflowType.Equal("c"))),
+			"WorkflowType = 'a' OR WorkflowType = 'b' OR WorkflowType = 'c'",
+		},
+		{"empty operands", VisibilityQuery{}.And(workflowType.Equal("a").Or(closeTime.IsNull()), VisibilityQuery{}), "WorkflowType = 'a' OR CloseTime IS NULL"},
+		{
+			"order by",
+			workflowType.Equal("a").OrderBy(startTime, true).And(closeTime.IsNull().OrderBy(workflowType, false)),
+			"WorkflowType = 'a' AND CloseTime IS NULL ORDER BY StartTime DESC, WorkflowType ASC",
+		},
+		{"only order by", VisibilityQuery{}.OrderBy(startTime, false), "ORDER BY StartTime ASC"},
+		{"in nothing", workflowType.In(), "WorkflowType IS NULL AND WorkflowType IS NOT NULL"},
+		{
+			"or in nothing",
+			workflowType.Equal("a").Or(status.In()),
+			"WorkflowType = 'a' OR (ExecutionStatus IS NULL AND ExecutionStatus IS NOT NULL)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.query.String())
+		})
+	}
+
+	// Queries are values, ordering one doesn't change another.
+	base := workflowType.Equal("a").OrderBy(startTime, false)
+	byCloseTime := base.OrderBy(closeTime, false)
+	byType := base.OrderBy(workflowType, true)
+	require.Equal(t, "WorkflowType = 'a' ORDER BY StartTime ASC, CloseTime ASC", byCloseTime.String())
+	require.Equal(t, "WorkflowType = 'a' ORDER BY StartTime ASC, WorkflowType DESC", byType.String())
+}
@@ -94,6 +94,23 @@ type (
 		DefaultVersioningBehavior VersioningBehavior
 	}
 
+	// DrainProgress describes how far along a draining worker is.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.DrainProgress]
+	DrainProgress struct {
+		// InFlightActivities is the number of activity tasks, including session
+		// activities, that are still executing or waiting to execute, plus the
+		// activity polls that are still outstanding.
+		InFlightActivities int
+		// InFlightNexusTasks is the number of Nexus tasks that are still executing
+		// or waiting to execute, plus the Nexus polls that are still outstanding.
+		InFlightNexusTasks int
+		// Elapsed is the time since Drain was called.
+		Elapsed time.Duration
+	}
+
 	// WorkerOptions is used to configure a worker instance.
 	// The current timeout resolution implementation is in seconds and uses math.Ceil(d.Seconds()) as the duration. But is
 	// subjected to change in the future.
@@ -116,6 +133,15 @@ type (
 		// default: 100k
 		WorkerActivitiesPerSecond float64
 
+		// Optional: Rate limits activity executions by a key extracted from each
+		// activity task, such as the activity type or a header value. Throttled
+		// activities are delayed on the worker rather than failed, and the delay
+		// counts towards their timeouts. They don't hold an activity slot while
+		// they are delayed.
+		//
+		// NOTE: Experimental
+		ActivityRateLimit ActivityRateLimitOptions
+
 		// Optional: To set the maximum concurrent local activity executions this worker can have.
 		// The zero value of this uses the default value.
 		//
@@ -196,6 +222,17 @@ type (
 		// default: false
 		EnableLoggingInReplay bool
 
+		// Optional: Samples and deduplicates lines written through this worker's
+		// logger, including the workflow and activity loggers, to keep hot loops
+		// and retrying activities from flooding logs. Works with any log.Logger.
+		// Dropped lines are counted in the temporal_worker_log_lines_dropped
+		// metric.
+		//
+		// default: no sampling or deduplication
+		//
+		// NOTE: Experimental
+		LogSampling LogSamplingOptions
+
 		// Optional: Sticky schedule to start timeout.
 		// The resolution is seconds.
 		//
@@ -289,10 +326,24 @@ type (
 		// here and in client options.
 		Interceptors []WorkerInterceptor
 
+		// Optional: Middleware called for each Nexus request before the operation handler, in order, e.g. to
+		// authorize callers or map them to tenants. Unlike interceptors, middleware sees the raw request
+		// options, headers and links and can reject the request with a [nexus.HandlerError].
+		//
+		// NOTE: Experimental
+		NexusMiddleware []NexusMiddleware
+
 		// Optional: Callback invoked on fatal error. Immediately after this
 		// returns, Worker.Stop() will be called.
 		OnFatalError func(error)
 
+		// Optional: Callback invoked periodically while worker.Drain is waiting
+		// for in-flight activity and Nexus tasks to complete, and once more when
+		// draining finishes.
+		//
+		// NOTE: Experimental
+		OnDrainProgress func(DrainProgress)
+
 		// Optional: Disable eager activities. If set to true, activities will not
 		// be requested to execute eagerly from the same workflow regardless of
 		// MaxConcurrentEagerActivityExecutionSize.
@@ -0,0 +1,283 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	"go.temporal.io/sdk/internal/common/util"
+)
+
+const (
+	workerHealthStatusOK          = "ok"
+	workerHealthStatusUnavailable = "unavailable"
+
+	workerStateNotStarted = "not_started"
+	workerStateRunning    = "running"
+	workerStateDraining   = "draining"
+	workerStateStopped    = "stopped"
+)
+
+type (
+	// WorkerHealthHandlerOptions configures the handler returned by
+	// NewWorkerHealthHandler.
+	//
+	// NOTE: Experimental
+	//
+	// Exposed as: [go.temporal.io/sdk/worker.HealthHandlerOptions]
+	WorkerHealthHandlerOptions struct {
+		// Optional: How recently each poller must have received a response from
+		// the server, with or without a task, for the worker to be considered
+		// ready. Pollers whose worker is busy executing tasks, and pollers of a
+		// worker started less than this ago, are considered recent.
+		//
+		// default: 140s (twice the long poll timeout)
+		PollRecencyThreshold time.Duration
+
+		// Optional: How long a poller whose worker isn't executing any task may go
+		// without any poll returning, successfully or not, before it is considered
+		// stuck and the worker is no longer considered live.
+		//
+		// default: 140s (twice the long poll timeout)
+		StuckPollerThreshold time.Duration
+	}
+
+	workerHealthHandler struct {
+		workers              []*AggregatedWorker
+		pollRecencyThreshold time.Duration
+		stuckPollerThreshold time.Duration
+		// Returns the state of the worker's client connection, false if unknown
+		connectionState func(*AggregatedWorker) (connectivity.State, bool)
+	}
+
+	workerHealthResponse struct {
+		Status  string                `json:"status"`
+		Workers []workerHealthDetails `json:"workers"`
+	}
+
+	workerHealthDetails struct {
+		TaskQueue                    string                `json:"taskQueue"`
+		Identity                     string                `json:"identity"`
+		State                        string                `json:"state"`
+		Connection                   string                `json:"connection,omitempty"`
+		Live                         bool                  `json:"live"`
+		Ready                        bool                  `json:"ready"`
+		DeadlockedWorkflowGoroutines int64                 `json:"deadlockedWorkflowGoroutines"`
+		Problems                     []string              `json:"problems,omitempty"`
+		Pollers                      []pollerHealthDetails `json:"pollers,omitempty"`
+	}
+
+	pollerHealthDetails struct {
+		WorkerType         string     `json:"workerType"`
+		TaskQueue          string     `json:"taskQueue"`
+		LastSuccessfulPoll *time.Time `json:"lastSuccessfulPoll,omitempty"`
+		LastPollError      string     `json:"lastPollError,omitempty"`
+		SlotsUsed          int        `json:"slotsUsed"`
+	}
+)
+
+// NewWorkerHealthHandler returns an HTTP handler that serves liveness and
+// readiness for the given workers.
+func NewWorkerHealthHandler(options WorkerHealthHandlerOptions, workers ...*AggregatedWorker) http.Handler {
+	h := &workerHealthHandler{
+		workers:              workers,
+		pollRecencyThreshold: options.PollRecencyThreshold,
+		stuckPollerThreshold: options.StuckPollerThreshold,
+		connectionState:      workerConnectionState,
+	}
+	if h.pollRecencyThreshold <= 0 {
+		h.pollRecencyThreshold = 2 * pollTaskServiceTimeOut
+	}
+	if h.stuckPollerThreshold <= 0 {
+		h.stuckPollerThreshold = 2 * pollTaskServiceTimeOut
+	}
+	return h
+}
+
+func (h *workerHealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var readiness bool
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/livez"):
+	case strings.HasSuffix(r.URL.Path, "/readyz"):
+		readiness = true
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	resp := h.check()
+	healthy := true
+	for _, details := range resp.Workers {
+		if (readiness && !details.Ready) || !details.Live {
+			healthy = false
+		}
+	}
+	resp.Status = workerHealthStatusOK
+	code := http.StatusOK
+	if !healthy {
+		resp.Status = workerHealthStatusUnavailable
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *workerHealthHandler) check() *workerHealthResponse {
+	resp := &workerHealthResponse{
+		Workers: make([]workerHealthDetails, 0, len(h.workers)),
+	}
+	for _, aw := range h.workers {
+		resp.Workers = append(resp.Workers, h.checkWorker(aw))
+	}
+	return resp
+}
+
+func (h *workerHealthHandler) checkWorker(aw *AggregatedWorker) workerHealthDetails {
+	details := workerHealthDetails{
+		TaskQueue: aw.executionParams.TaskQueue,
+		Identity:  aw.executionParams.Identity,
+		State:     workerStateRunning,
+		Live:      true,
+		Ready:     true,
+	}
+	notReady := func(problem string) {
+		details.Ready = false
+		details.Problems = append(details.Problems, problem)
+	}
+	notLive := func(problem string) {
+		details.Live = false
+		notReady(problem)
+	}
+
+	select {
+	case <-aw.stopC:
+		details.State = workerStateStopped
+		aw.fatalErrLock.Lock()
+		fatalErr := aw.fatalErr
+		aw.fatalErrLock.Unlock()
+		if fatalErr != nil {
+			notLive(fmt.Sprintf("worker stopped on fatal error: %v", fatalErr))
+		} else {
+			notLive("worker stopped")
+		}
+		return details
+	default:
+	}
+	if !aw.started.Load() {
+		details.State = workerStateNotStarted
+		notReady("worker not started")
+		return details
+	}
+	if aw.draining.Load() {
+		details.State = workerStateDraining
+		notReady("worker draining")
+	}
+	if deadlocked := aw.executionParams.deadlockedCoroutines; deadlocked != nil {
+		details.DeadlockedWorkflowGoroutines = deadlocked.Load()
+		if details.DeadlockedWorkflowGoroutines > 0 {
+			notLive(fmt.Sprintf("%d workflow goroutines are deadlocked", details.DeadlockedWorkflowGoroutines))
+		}
+	}
+	if state, ok := h.connectionState(aw); ok {
+		details.Connection = strings.ToLower(state.String())
+		if state != connectivity.Ready {
+			notReady("client connection is " + details.Connection)
+		}
+	}
+
+	now := time.Now()
+	for _, p := range aw.pollingWorkers() {
+		bwh := p.worker.health()
+		var lastResponse, lastCompletion time.Time
+		if tracker := aw.executionParams.pollTimeTracker; tracker != nil {
+			for _, pollerType := range p.pollerTypes {
+				lastResponse = latestTime(lastResponse, tracker.getLastPollResponseTime(pollerType))
+				lastCompletion = latestTime(lastCompletion, tracker.getLastPollCompletionTime(pollerType))
+			}
+		}
+		poller := pollerHealthDetails{
+			WorkerType:    p.worker.options.workerType,
+			TaskQueue:     p.taskQueue,
+			LastPollError: bwh.lastPollError,
+			SlotsUsed:     bwh.slotsUsed,
+		}
+		if !lastResponse.IsZero() {
+			poller.LastSuccessfulPoll = &lastResponse
+		}
+		details.Pollers = append(details.Pollers, poller)
+
+		// Pollers that are not running or are draining are not expected to poll,
+		// and pollers busy executing tasks may be waiting on a slot rather than
+		// polling
+		if bwh.startedAt.IsZero() || bwh.draining || bwh.slotsUsed > 0 {
+			continue
+		}
+		if sincePoll := now.Sub(latestTime(lastCompletion, bwh.startedAt)); sincePoll > h.stuckPollerThreshold {
+			notLive(fmt.Sprintf("%s poller on %s has not completed a poll in %v",
+				poller.WorkerType, poller.TaskQueue, sincePoll.Truncate(time.Second)))
+			continue
+		}
+		if bwh.lastPollError != "" {
+			notReady(fmt.Sprintf("%s poller on %s failed to poll: %s", poller.WorkerType, poller.TaskQueue, bwh.lastPollError))
+			continue
+		}
+		if now.Sub(latestTime(lastResponse, bwh.startedAt)) > h.pollRecencyThreshold {
+			notReady(fmt.Sprintf("%s poller on %s has not polled recently", poller.WorkerType, poller.TaskQueue))
+		}
+	}
+	return details
+}
+
+// workerConnectionState returns the state of the gRPC connection of the worker's
+// client, if it owns one.
+func workerConnectionState(aw *AggregatedWorker) (connectivity.State, bool) {
+	if aw.client == nil || aw.client.conn == nil {
+		return 0, false
+	}
+	return aw.client.conn.GetState(), true
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+type pollingWorker struct {
+	worker      *baseWorker
+	taskQueue   string
+	pollerTypes []string
+}
+
+// pollingWorkers returns the base workers that poll the server for tasks.
+func (aw *AggregatedWorker) pollingWorkers() []pollingWorker {
+	var workers []pollingWorker
+	if !util.IsInterfaceNil(aw.workflowWorker) {
+		workers = append(workers, pollingWorker{aw.workflowWorker.worker, aw.workflowWorker.executionParameters.TaskQueue,
+			[]string{metrics.PollerTypeWorkflowTask, metrics.PollerTypeWorkflowStickyTask}})
+	}
+	if !util.IsInterfaceNil(aw.activityWorker) {
+		workers = append(workers, pollingWorker{aw.activityWorker.worker, aw.activityWorker.executionParameters.TaskQueue,
+			[]string{metrics.PollerTypeActivityTask}})
+	}
+	if !util.IsInterfaceNil(aw.sessionWorker) {
+		for _, w := range []*activityWorker{aw.sessionWorker.creationWorker, aw.sessionWorker.activityWorker} {
+			workers = append(workers, pollingWorker{w.worker, w.executionParameters.TaskQueue,
+				[]string{metrics.PollerTypeActivityTask}})
+		}
+	}
+	if !util.IsInterfaceNil(aw.nexusWorker) {
+		workers = append(workers, pollingWorker{aw.nexusWorker.worker, aw.nexusWorker.executionParameters.TaskQueue,
+			[]string{metrics.PollerTypeNexusTask}})
+	}
+	return workers
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+
+	"go.temporal.io/sdk/internal/common/metrics"
+	ilog "go.temporal.io/sdk/internal/log"
+)
+
+func newHealthTestWorker(t *testing.T) (*AggregatedWorker, *baseWorker) {
+	slotSupplier, err := NewFixedSizeSlotSupplier(10)
+	require.NoError(t, err)
+	bw := newBaseWorker(baseWorkerOptions{
+		slotSupplier:   slotSupplier,
+		workerType:     "ActivityWorker",
+		logger:         ilog.NewNopLogger(),
+		metricsHandler: metrics.NopHandler,
+	})
+	aw := &AggregatedWorker{
+		executionParams: workerExecutionParameters{
+			TaskQueue:            "tq",
+			Identity:             "test-identity",
+			pollTimeTracker:      &pollTimeTracker{},
+			deadlockedCoroutines: &atomic.Int64{},
+		},
+		activityWorker: &activityWorker{
+			executionParameters: workerExecutionParameters{TaskQueue: "tq"},
+			worker:              bw,
+		},
+		stopC: make(chan struct{}),
+	}
+	return aw, bw
+}
+
+func getWorkerHealth(t *testing.T, h http.Handler, path string) (int, *workerHealthResponse) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code == http.StatusNotFound {
+		return rec.Code, nil
+	}
+	var resp workerHealthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, &resp
+}
+
+func TestWorkerHealthHandler(t *testing.T) {
+	aw, bw := newHealthTestWorker(t)
+	h := NewWorkerHealthHandler(WorkerHealthHandlerOptions{
+		PollRecencyThreshold: time.Minute,
+		StuckPollerThreshold: time.Minute,
+	}, aw).(*workerHealthHandler)
+	connection := connectivity.Ready
+	h.connectionState = func(*AggregatedWorker) (connectivity.State, bool) { return connection, true }
+	tracker := aw.executionParams.pollTimeTracker
+
+	// Not started is live but not ready
+	code, resp := getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, workerHealthStatusOK, resp.Status)
+	code, resp = getWorkerHealth(t, h, "/health/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, workerStateNotStarted, resp.Workers[0].State)
+
+	// Just started is ready until the first poll returns
+	aw.started.Store(true)
+	bw.startedAt.Store(time.Now().UnixNano())
+	code, resp = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, workerStateRunning, resp.Workers[0].State)
+	require.Equal(t, "ready", resp.Workers[0].Connection)
+	require.Len(t, resp.Workers[0].Pollers, 1)
+	require.Equal(t, "ActivityWorker", resp.Workers[0].Pollers[0].WorkerType)
+	require.Nil(t, resp.Workers[0].Pollers[0].LastSuccessfulPoll)
+
+	// Started long ago and recently polled is ready
+	bw.startedAt.Store(time.Now().Add(-time.Hour).UnixNano())
+	tracker.recordPollCompletion(metrics.PollerTypeActivityTask, nil)
+	code, resp = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.NotNil(t, resp.Workers[0].Pollers[0].LastSuccessfulPoll)
+
+	// A failing poll is not ready but still live
+	bw.lastPollTaskErrMessage = "connection refused"
+	tracker.recordPollCompletion(metrics.PollerTypeActivityTask, errors.New("connection refused"))
+	code, resp = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "connection refused", resp.Workers[0].Pollers[0].LastPollError)
+	code, _ = getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusOK, code)
+	bw.lastPollTaskErrMessage = ""
+
+	// No recent poll response is not ready
+	tracker.responseTimes.Store(metrics.PollerTypeActivityTask, time.Now().Add(-time.Hour).UnixNano())
+	code, _ = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusOK, code)
+
+	// No poll returning for too long is a stuck poller, unless the worker is
+	// busy executing tasks
+	tracker.completionTimes.Store(metrics.PollerTypeActivityTask, time.Now().Add(-time.Hour).UnixNano())
+	code, resp = getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, resp.Workers[0].Live)
+	permit := bw.tryReserveSlot()
+	require.NotNil(t, permit)
+	bw.slotSupplier.MarkSlotUsed(permit)
+	code, _ = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	bw.releaseSlot(permit, SlotReleaseReasonTaskProcessed)
+	tracker.recordPollCompletion(metrics.PollerTypeActivityTask, nil)
+	code, _ = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+
+	// A client that isn't connected is live but not ready
+	connection = connectivity.TransientFailure
+	code, resp = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "transient_failure", resp.Workers[0].Connection)
+	code, _ = getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusOK, code)
+	connection = connectivity.Ready
+
+	// Draining is live but not ready
+	aw.draining.Store(true)
+	code, resp = getWorkerHealth(t, h, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, workerStateDraining, resp.Workers[0].State)
+	code, _ = getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusOK, code)
+
+	// Stopped on fatal error is not live
+	aw.fatalErr = errors.New("namespace not found")
+	close(aw.stopC)
+	code, resp = getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, workerStateStopped, resp.Workers[0].State)
+	require.Contains(t, resp.Workers[0].Problems[0], "namespace not found")
+
+	code, _ = getWorkerHealth(t, h, "/metrics")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestWorkerHealthHandlerDeadlock(t *testing.T) {
+	aw, bw := newHealthTestWorker(t)
+	other, otherBW := newHealthTestWorker(t)
+	for _, w := range []*AggregatedWorker{aw, other} {
+		w.started.Store(true)
+	}
+	bw.startedAt.Store(time.Now().UnixNano())
+	otherBW.startedAt.Store(time.Now().UnixNano())
+	h := NewWorkerHealthHandler(WorkerHealthHandlerOptions{}, aw, other)
+
+	code, _ := getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusOK, code)
+
+	// Only the worker with the deadlocked workflow goroutine is not live
+	aw.executionParams.deadlockedCoroutines.Store(1)
+	code, resp := getWorkerHealth(t, h, "/livez")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, int64(1), resp.Workers[0].DeadlockedWorkflowGoroutines)
+	require.False(t, resp.Workers[0].Live)
+	require.True(t, resp.Workers[1].Live)
+}
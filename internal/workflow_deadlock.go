@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	commonpb "go.temporal.io/api/common/v1"
@@ -13,6 +14,10 @@ type deadlockDetector struct {
 	lock    sync.RWMutex // Applies to all fields below
 	tickers map[*deadlockTicker]struct{}
 	paused  bool
+
+	// Number of coroutines of the worker that were detected as deadlocked and
+	// have not yet yielded or exited, nil if not tracked.
+	deadlockedCoroutines *atomic.Int64
 }
 
 type deadlockTicker struct {
@@ -59,8 +64,21 @@ func getDeadlockDetector(ctx Context) *deadlockDetector {
 	return nil
 }
 
-func newDeadlockDetector() *deadlockDetector {
-	return &deadlockDetector{tickers: map[*deadlockTicker]struct{}{}}
+func newDeadlockDetector(deadlockedCoroutines *atomic.Int64) *deadlockDetector {
+	return &deadlockDetector{tickers: map[*deadlockTicker]struct{}{}, deadlockedCoroutines: deadlockedCoroutines}
+}
+
+// trackDeadlocked counts a coroutine detected as deadlocked until it yields or
+// exits, which is signaled by unblocked.
+func (d *deadlockDetector) trackDeadlocked(unblocked <-chan bool) {
+	if d.deadlockedCoroutines == nil {
+		return
+	}
+	d.deadlockedCoroutines.Add(1)
+	go func() {
+		<-unblocked
+		d.deadlockedCoroutines.Add(-1)
+	}()
 }
 
 // begin starts a new deadlock detection ticker which may start as paused
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,7 +14,7 @@ import (
 func TestDeadlockDetector(t *testing.T) {
 	// Create a 500ms ticker and confirm it pauses/resumes properly. We have
 	// chosen to use real time instead of an abstract clock here for simplicity.
-	d := newDeadlockDetector()
+	d := newDeadlockDetector(nil)
 	ticker := d.begin(500 * time.Millisecond)
 	defer ticker.end()
 	d.pause()
@@ -118,3 +119,18 @@ func TestDataConverterWithoutDeadlockDetectionContext(t *testing.T) {
 	})
 
 }
+
+func TestDeadlockDetectorTracksDeadlockedCoroutines(t *testing.T) {
+	var deadlocked atomic.Int64
+	d := newDeadlockDetector(&deadlocked)
+	unblocked := make(chan bool, 1)
+	d.trackDeadlocked(unblocked)
+
+	// The coroutine is counted until it yields or exits
+	require.Equal(t, int64(1), deadlocked.Load())
+	unblocked <- true
+	require.Eventually(t, func() bool { return deadlocked.Load() == 0 }, time.Second, 10*time.Millisecond)
+
+	// Detectors of environments that don't track deadlocks ignore them
+	newDeadlockDetector(nil).trackDeadlocked(unblocked)
+}
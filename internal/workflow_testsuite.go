@@ -326,7 +326,10 @@ func (e *TestWorkflowEnvironment) RegisterDynamicActivity(a interface{}, options
 	e.impl.RegisterDynamicActivity(a, options)
 }
 
-// RegisterNexusService registers a Nexus Service with the TestWorkflowEnvironment.
+// RegisterNexusService registers a Nexus Service with the TestWorkflowEnvironment. Operations called from the
+// workflow under test are handled by the service, and workflows started by operations such as
+// [go.temporal.io/sdk/temporalnexus.NewWorkflowRunOperation] run in the same environment with time skipping, complete
+// the operation asynchronously and are canceled according to the caller's NexusOperationCancellationType.
 func (e *TestWorkflowEnvironment) RegisterNexusService(s *nexus.Service) {
 	e.impl.RegisterNexusService(s)
 }
@@ -0,0 +1,757 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/sdk/converter"
+)
+
+// fakeClientHostWorkflowType is the workflow type recorded on an environment that hosts a fake client, so that it
+// can't also be used to execute a workflow.
+const fakeClientHostWorkflowType = "FakeClientHost"
+
+var errFakeClientClosed = errors.New("fake client is closed")
+
+type (
+	// testFakeClient implements [Client] on top of a test workflow environment. Workflows started with it run as
+	// detached workflows of the environment, whose main loop runs in the background until the client is closed.
+	// Methods that aren't supported return a serviceerror.Unimplemented error.
+	testFakeClient struct {
+		testSuiteClientForNexusOperations
+
+		stopCh    chan struct{}
+		loopDone  chan struct{}
+		closeOnce sync.Once
+
+		// The following fields are only accessed in the main loop.
+		waiting   []<-chan struct{}
+		runs      map[string]*testFakeWorkflowRun
+		updates   map[testFakeUpdateKey]*testFakeUpdateHandle
+		schedules map[string]*testFakeSchedule
+	}
+
+	// testFakeWorkflowRun is the [WorkflowRun] of a workflow started with a fake client. It completes with the
+	// last run of the workflow.
+	testFakeWorkflowRun struct {
+		WorkflowExecution
+		client       *testFakeClient
+		workflowType string
+		done         chan struct{}
+		result       *commonpb.Payloads
+		err          error
+		// onComplete, if set, is called in the main loop once the run completes.
+		onComplete func()
+	}
+
+	// testFakeUpdateKey identifies an update of a workflow run, since update IDs are only unique per run like on the
+	// server.
+	testFakeUpdateKey struct {
+		workflowID string
+		runID      string
+		updateID   string
+	}
+
+	// testFakeUpdateHandle is the [WorkflowUpdateHandle] of an update sent with a fake client.
+	testFakeUpdateHandle struct {
+		client     *testFakeClient
+		workflowID string
+		runID      string
+		updateID   string
+
+		acceptedOnce sync.Once
+		accepted     chan struct{}
+		// decided is closed once the update is accepted or rejected.
+		decidedOnce sync.Once
+		decided     chan struct{}
+		doneOnce    sync.Once
+		done        chan struct{}
+		result      *commonpb.Payloads
+		err         error
+	}
+
+	// testFakeClientHostDefinition is the workflow definition of an environment hosting a fake client, which runs
+	// no workflow code of its own.
+	testFakeClientHostDefinition struct{}
+)
+
+// NewFakeClient creates a [Client] that runs workflows in the given test environment instead of on a server. The
+// workflows, activities and Nexus services registered with the environment really run, including mocks and listeners,
+// and workflows started with the client can be signaled, queried, updated, canceled and terminated through it.
+//
+// Time is only skipped while a call waits for the result of a workflow or update, like the time skipping test server
+// does, so a timer in a workflow that no one is waiting for doesn't fire. Configure the environment before creating
+// the client. The environment is used by the client only and can't execute a workflow itself. Close the client at the
+// end of the test, which terminates the workflows that are still running.
+//
+// Schedules created with the client start their workflows as the simulated time passes, see [SleepFakeClient].
+//
+// The supported methods are ExecuteWorkflow, GetWorkflow, SignalWorkflow, SignalWithStartWorkflow, CancelWorkflow,
+// TerminateWorkflow, QueryWorkflow, UpdateWorkflow, GetWorkflowUpdateHandle, ScheduleClient, CheckHealth and Close.
+// The other methods need a server, such as DescribeWorkflow, those listing workflows or activities and
+// GetWorkflowHistory, and return a [serviceerror.Unimplemented] error, as do the service clients and deployment
+// clients the client returns.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/testsuite.NewFakeClient]
+func NewFakeClient(env *TestWorkflowEnvironment) Client {
+	impl := env.impl
+	impl.locker.Lock()
+	if impl.workflowInfo.WorkflowType.Name != workflowTypeNotSpecified {
+		impl.locker.Unlock()
+		panic(fmt.Sprintf("Current TestWorkflowEnvironment is used to execute %v. Please create a new TestWorkflowEnvironment for a fake client.", impl.workflowInfo.WorkflowType.Name))
+	}
+	impl.workflowInfo.WorkflowType.Name = fakeClientHostWorkflowType
+	impl.workflowDef = testFakeClientHostDefinition{}
+	impl.hostsFakeClient = true
+	// Time is locked until a call waits for a result.
+	impl.runningCount++
+	impl.locker.Unlock()
+
+	c := &testFakeClient{
+		testSuiteClientForNexusOperations: testSuiteClientForNexusOperations{env: impl},
+		stopCh:                            make(chan struct{}),
+		loopDone:                          make(chan struct{}),
+		runs:                              make(map[string]*testFakeWorkflowRun),
+		updates:                           make(map[testFakeUpdateKey]*testFakeUpdateHandle),
+		schedules:                         make(map[string]*testFakeSchedule),
+	}
+	go c.mainLoop()
+	return c
+}
+
+// SleepFakeClient skips the given duration of the simulated time of a client created with [NewFakeClient], during
+// which timers fire and schedules take their actions. Like waiting for a result, it blocks until the time has passed,
+// which takes longer while activities are running. It panics if the client is not a fake client.
+//
+// NOTE: Experimental
+//
+// Exposed as: [go.temporal.io/sdk/testsuite.SleepFakeClient]
+func SleepFakeClient(ctx context.Context, client Client, d time.Duration) error {
+	c, ok := client.(*testFakeClient)
+	if !ok {
+		panic("SleepFakeClient requires a client created with NewFakeClient")
+	}
+	if d <= 0 {
+		return nil
+	}
+	done := make(chan struct{})
+	if err := c.do(func() {
+		c.env.newTimer(d, TimerOptions{}, func(*commonpb.Payloads, error) {
+			close(done)
+		}, false)
+	}); err != nil {
+		return err
+	}
+	return c.wait(ctx, done)
+}
+
+// mainLoop processes the callbacks of the environment and fires its timers until the client is closed. Unlike the main
+// loop of a workflow, it doesn't time out when there is nothing to do.
+func (c *testFakeClient) mainLoop() {
+	defer close(c.loopDone)
+	env := c.env
+	defer env.closeDoneChannel()
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case cb := <-env.callbackChannel:
+			cb.processCallback()
+			continue
+		default:
+		}
+		c.releaseWaiting()
+		if env.autoFireNextTimer() {
+			continue
+		}
+		select {
+		case <-c.stopCh:
+			return
+		case cb := <-env.callbackChannel:
+			cb.processCallback()
+		}
+	}
+}
+
+// do runs f in the main loop and waits for it to return.
+func (c *testFakeClient) do(f func()) error {
+	done := make(chan struct{})
+	select {
+	case <-c.stopCh:
+		return errFakeClientClosed
+	default:
+	}
+	c.env.postCallback(func() {
+		defer close(done)
+		f()
+	}, false)
+	select {
+	case <-done:
+		return nil
+	case <-c.loopDone:
+		return errFakeClientClosed
+	}
+}
+
+// wait waits for done to be closed, skipping time in the meantime.
+func (c *testFakeClient) wait(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+	if err := c.do(func() {
+		c.waiting = append(c.waiting, done)
+		if len(c.waiting) == 1 {
+			c.env.runningCount--
+		}
+	}); err != nil {
+		return err
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		_ = c.do(func() {
+			for i, waiting := range c.waiting {
+				if waiting == done {
+					c.waiting = append(c.waiting[:i], c.waiting[i+1:]...)
+					c.lockTimeIfNotWaiting()
+					break
+				}
+			}
+		})
+		return ctx.Err()
+	case <-c.loopDone:
+		return errFakeClientClosed
+	}
+}
+
+// releaseWaiting stops waiting for the channels that are closed, so that no timer fires after a waited for result
+// and before the call returns.
+func (c *testFakeClient) releaseWaiting() {
+	if len(c.waiting) == 0 {
+		return
+	}
+	waiting := c.waiting[:0]
+	for _, done := range c.waiting {
+		select {
+		case <-done:
+		default:
+			waiting = append(waiting, done)
+		}
+	}
+	c.waiting = waiting
+	c.lockTimeIfNotWaiting()
+}
+
+func (c *testFakeClient) lockTimeIfNotWaiting() {
+	if len(c.waiting) == 0 {
+		c.env.runningCount++
+	}
+}
+
+// ExecuteWorkflow implements Client.
+func (c *testFakeClient) ExecuteWorkflow(ctx context.Context, options StartWorkflowOptions, workflow interface{}, args ...interface{}) (WorkflowRun, error) {
+	return c.startWorkflow(ctx, options, workflow, args, nil)
+}
+
+// startWorkflow starts a workflow. If set, started is called in the same call of the main loop once the workflow is
+// started, with the data converter of the workflow.
+func (c *testFakeClient) startWorkflow(
+	ctx context.Context,
+	options StartWorkflowOptions,
+	workflow interface{},
+	args []interface{},
+	started func(dc converter.DataConverter) error,
+) (*testFakeWorkflowRun, error) {
+	if options.TaskQueue == "" {
+		return nil, serviceerror.NewInvalidArgument("missing task queue name")
+	}
+	if options.ID == "" {
+		options.ID = uuid.NewString()
+	}
+	dc := converter.WithDataConverterSerializationContext(c.env.dataConverter, converter.WorkflowSerializationContext{
+		Namespace:  c.env.workflowInfo.Namespace,
+		WorkflowID: options.ID,
+	})
+	workflowType, input, err := getValidatedWorkflowFunction(workflow, args, dc, c.env.registry)
+	if err != nil {
+		return nil, err
+	}
+	header, err := headerPropagated(contextWithNewHeader(ctx), c.env.contextPropagators)
+	if err != nil {
+		return nil, err
+	}
+
+	var run *testFakeWorkflowRun
+	if doErr := c.do(func() {
+		run, err = c.startWorkflowInLoop(options, *workflowType, input, header, dc)
+		if err == nil && started != nil {
+			err = started(dc)
+		}
+	}); doErr != nil {
+		return nil, doErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// startWorkflowInLoop starts a workflow with encoded input from the main loop.
+func (c *testFakeClient) startWorkflowInLoop(
+	options StartWorkflowOptions,
+	workflowType WorkflowType,
+	input *commonpb.Payloads,
+	header *commonpb.Header,
+	dc converter.DataConverter,
+) (*testFakeWorkflowRun, error) {
+	if _, ok := c.env.registry.getWorkflowFn(workflowType.Name); !ok {
+		return nil, fmt.Errorf("unable to find workflow type: %v", workflowType.Name)
+	}
+	started := &testFakeWorkflowRun{
+		client:       c,
+		workflowType: workflowType.Name,
+		done:         make(chan struct{}),
+	}
+	var err error
+	starting := true
+	childEnv := c.env.executeChildWorkflowWithDelay(options.StartDelay, ExecuteWorkflowParams{
+		WorkflowOptions: WorkflowOptions{
+			Namespace:                c.env.workflowInfo.Namespace,
+			TaskQueueName:            options.TaskQueue,
+			WorkflowID:               options.ID,
+			WorkflowExecutionTimeout: options.WorkflowExecutionTimeout,
+			WorkflowRunTimeout:       options.WorkflowRunTimeout,
+			WorkflowTaskTimeout:      options.WorkflowTaskTimeout,
+			DataConverter:            dc,
+			WorkflowIDReusePolicy:    options.WorkflowIDReusePolicy,
+			WorkflowIDConflictPolicy: options.WorkflowIDConflictPolicy,
+			OnConflictOptions:        options.onConflictOptions,
+			ContextPropagators:       c.env.contextPropagators,
+			SearchAttributes:         options.SearchAttributes,
+			TypedSearchAttributes:    options.TypedSearchAttributes,
+			ParentClosePolicy:        enumspb.PARENT_CLOSE_POLICY_TERMINATE,
+			Memo:                     options.Memo,
+			CronSchedule:             options.CronSchedule,
+			RetryPolicy:              convertToPBRetryPolicy(options.RetryPolicy),
+			Priority:                 convertToPBPriority(options.Priority),
+		},
+		WorkflowType: &workflowType,
+		Input:        input,
+		Header:       header,
+	}, started.complete, func(_ WorkflowExecution, startErr error) {
+		// Only errors reported while starting are returned, later ones come from mocks and end the run.
+		if starting && startErr != nil {
+			err = startErr
+		}
+	})
+	starting = false
+	if err != nil {
+		return nil, err
+	}
+	if childEnv == nil {
+		// The workflow is already running and the conflict policy is to use it.
+		return c.runs[options.ID], nil
+	}
+	started.WorkflowExecution = childEnv.workflowInfo.WorkflowExecution
+	c.runs[options.ID] = started
+	return started, nil
+}
+
+// GetWorkflow implements Client.
+func (c *testFakeClient) GetWorkflow(ctx context.Context, workflowID string, runID string) WorkflowRun {
+	var run *testFakeWorkflowRun
+	_ = c.do(func() {
+		run = c.runs[workflowID]
+	})
+	if run == nil || (runID != "" && runID != run.RunID) {
+		run = &testFakeWorkflowRun{
+			WorkflowExecution: WorkflowExecution{ID: workflowID, RunID: runID},
+			client:            c,
+			done:              make(chan struct{}),
+			err:               serviceerror.NewNotFound(fmt.Sprintf("Workflow %v not exists", workflowID)),
+		}
+		close(run.done)
+	}
+	return run
+}
+
+// withWorkflow calls f in the main loop with the workflow of the given ID once its handlers are set. It fails if the
+// run ID is set and isn't the one of the last run, and unless allowCompleted is set, if the workflow is completed.
+func (c *testFakeClient) withWorkflow(workflowID, runID string, allowCompleted bool, f func(handle *testWorkflowHandle)) error {
+	for {
+		var executed chan struct{}
+		var err error
+		if doErr := c.do(func() {
+			handle, ok := c.env.runningWorkflows[workflowID]
+			if !ok {
+				err = serviceerror.NewNotFound(fmt.Sprintf("Workflow %v not exists", workflowID))
+				return
+			}
+			if runID != "" && runID != handle.env.workflowInfo.WorkflowExecution.RunID {
+				err = serviceerror.NewNotFound(fmt.Sprintf("Workflow %v with run %v not exists", workflowID, runID))
+				return
+			}
+			if handle.handled && !allowCompleted {
+				err = serviceerror.NewNotFound(fmt.Sprintf("Workflow %v already completed", workflowID))
+				return
+			}
+			select {
+			case <-handle.env.workflowDefExecuted:
+				f(handle)
+			default:
+				executed = handle.env.workflowDefExecuted
+			}
+		}); doErr != nil {
+			return doErr
+		}
+		if err != nil || executed == nil {
+			return err
+		}
+		select {
+		case <-executed:
+		case <-c.loopDone:
+			return errFakeClientClosed
+		}
+	}
+}
+
+// SignalWorkflow implements Client.
+func (c *testFakeClient) SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error {
+	var err error
+	if workflowErr := c.withWorkflow(workflowID, runID, false, func(*testWorkflowHandle) {
+		err = c.env.signalWorkflowByID(workflowID, signalName, arg)
+	}); workflowErr != nil {
+		return workflowErr
+	}
+	return err
+}
+
+// SignalWithStartWorkflow implements Client.
+func (c *testFakeClient) SignalWithStartWorkflow(ctx context.Context, workflowID string, signalName string, signalArg interface{}, options StartWorkflowOptions, workflow interface{}, workflowArgs ...interface{}) (WorkflowRun, error) {
+	options.ID = workflowID
+	options.WorkflowIDConflictPolicy = enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING
+	// Like the server, start and signal at once so that a new run gets the signal before its first workflow task.
+	run, err := c.startWorkflow(ctx, options, workflow, workflowArgs, func(dc converter.DataConverter) error {
+		data, err := encodeArg(dc, signalArg)
+		if err != nil {
+			return err
+		}
+		handle := c.env.runningWorkflows[workflowID]
+		signal := func() {
+			// Do not send any headers on test invocations
+			_ = handle.env.signalHandler(signalName, data, nil)
+		}
+		select {
+		case <-handle.env.workflowDefExecuted:
+			handle.env.postCallback(signal, true)
+		default:
+			handle.env.signalsWithStart = append(handle.env.signalsWithStart, signal)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// CancelWorkflow implements Client.
+func (c *testFakeClient) CancelWorkflow(ctx context.Context, workflowID string, runID string) error {
+	return c.withWorkflow(workflowID, runID, false, func(handle *testWorkflowHandle) {
+		handle.env.cancelWorkflow(func(result *commonpb.Payloads, err error) {})
+	})
+}
+
+// TerminateWorkflow implements Client.
+func (c *testFakeClient) TerminateWorkflow(ctx context.Context, workflowID string, runID string, reason string, details ...interface{}) error {
+	return c.withWorkflow(workflowID, runID, false, func(handle *testWorkflowHandle) {
+		handle.env.Complete(nil, newTerminatedError())
+	})
+}
+
+// QueryWorkflow implements Client.
+func (c *testFakeClient) QueryWorkflow(ctx context.Context, workflowID string, runID string, queryType string, args ...interface{}) (converter.EncodedValue, error) {
+	var value converter.EncodedValue
+	var err error
+	if workflowErr := c.withWorkflow(workflowID, runID, true, func(*testWorkflowHandle) {
+		value, err = c.env.queryWorkflowByID(workflowID, queryType, args...)
+	}); workflowErr != nil {
+		return nil, workflowErr
+	}
+	return value, err
+}
+
+// UpdateWorkflow implements Client.
+func (c *testFakeClient) UpdateWorkflow(ctx context.Context, options UpdateWorkflowOptions) (WorkflowUpdateHandle, error) {
+	if options.WaitForStage == WorkflowUpdateStageUnspecified {
+		return nil, errors.New("WaitForStage must be specified")
+	}
+	if options.WaitForStage == WorkflowUpdateStageAdmitted {
+		return nil, errors.New("WaitForStage WorkflowUpdateStageAdmitted is not supported")
+	}
+	if options.UpdateID == "" {
+		options.UpdateID = uuid.NewString()
+	}
+
+	var handle *testFakeUpdateHandle
+	var err error
+	if workflowErr := c.withWorkflow(options.WorkflowID, options.RunID, false, func(workflowHandle *testWorkflowHandle) {
+		key := testFakeUpdateKey{
+			workflowID: options.WorkflowID,
+			runID:      workflowHandle.env.workflowInfo.WorkflowExecution.RunID,
+			updateID:   options.UpdateID,
+		}
+		if existing, ok := c.updates[key]; ok {
+			handle = existing
+			return
+		}
+		handle = &testFakeUpdateHandle{
+			client:     c,
+			workflowID: key.workflowID,
+			runID:      key.runID,
+			updateID:   key.updateID,
+			accepted:   make(chan struct{}),
+			decided:    make(chan struct{}),
+			done:       make(chan struct{}),
+		}
+		if err = c.env.updateWorkflowByID(
+			options.WorkflowID,
+			options.UpdateName,
+			options.UpdateID,
+			&TestUpdateCallback{
+				OnAccept:   handle.accept,
+				OnReject:   handle.reject,
+				OnComplete: handle.complete,
+			},
+			options.Args...,
+		); err != nil {
+			return
+		}
+		c.updates[key] = handle
+	}); workflowErr != nil {
+		return nil, workflowErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	waitFor := handle.decided
+	if options.WaitForStage == WorkflowUpdateStageCompleted {
+		waitFor = handle.done
+	}
+	if err := c.wait(ctx, waitFor); err != nil {
+		return nil, err
+	}
+	select {
+	case <-handle.accepted:
+	default:
+		// Rejected.
+		return nil, handle.err
+	}
+	return handle, nil
+}
+
+// GetWorkflowUpdateHandle implements Client.
+func (c *testFakeClient) GetWorkflowUpdateHandle(options GetWorkflowUpdateHandleOptions) WorkflowUpdateHandle {
+	var handle *testFakeUpdateHandle
+	_ = c.do(func() {
+		runID := options.RunID
+		if runID == "" {
+			if workflowHandle, ok := c.env.runningWorkflows[options.WorkflowID]; ok {
+				runID = workflowHandle.env.workflowInfo.WorkflowExecution.RunID
+			}
+		}
+		handle = c.updates[testFakeUpdateKey{workflowID: options.WorkflowID, runID: runID, updateID: options.UpdateID}]
+	})
+	if handle == nil {
+		handle = &testFakeUpdateHandle{
+			client:     c,
+			workflowID: options.WorkflowID,
+			runID:      options.RunID,
+			updateID:   options.UpdateID,
+			accepted:   make(chan struct{}),
+			decided:    make(chan struct{}),
+			done:       make(chan struct{}),
+		}
+		handle.complete(nil, serviceerror.NewNotFound(fmt.Sprintf("Update %v not exists", options.UpdateID)))
+	}
+	return handle
+}
+
+// CheckHealth implements Client.
+func (c *testFakeClient) CheckHealth(ctx context.Context, request *CheckHealthRequest) (*CheckHealthResponse, error) {
+	select {
+	case <-c.stopCh:
+		return nil, errFakeClientClosed
+	default:
+		return &CheckHealthResponse{}, nil
+	}
+}
+
+// Close implements Client. It terminates the workflows that are still running and stops the environment.
+func (c *testFakeClient) Close() {
+	c.closeOnce.Do(func() {
+		for {
+			// Workflows can only be terminated once executed, which runs just started by schedules may not be yet.
+			var executed chan struct{}
+			if doErr := c.do(func() {
+				for _, schedule := range c.schedules {
+					c.deleteSchedule(schedule)
+				}
+				for _, handle := range c.env.runningWorkflows {
+					if handle.env.parentEnv != c.env || handle.env.workflowDefExecuted == nil || handle.env.isWorkflowCompleted {
+						continue
+					}
+					select {
+					case <-handle.env.workflowDefExecuted:
+					default:
+						executed = handle.env.workflowDefExecuted
+						return
+					}
+				}
+				c.env.handleParentClosePolicy()
+			}); doErr != nil || executed == nil {
+				break
+			}
+			select {
+			case <-executed:
+			case <-c.loopDone:
+			}
+		}
+		close(c.stopCh)
+		<-c.loopDone
+	})
+}
+
+func (r *testFakeWorkflowRun) complete(result *commonpb.Payloads, err error) {
+	r.result = result
+	if err != nil {
+		var childErr *ChildWorkflowExecutionError
+		if errors.As(err, &childErr) {
+			err = childErr.Unwrap()
+		}
+		err = NewWorkflowExecutionError(r.ID, r.RunID, r.workflowType, err)
+	}
+	r.err = err
+	close(r.done)
+	if r.onComplete != nil {
+		r.onComplete()
+	}
+}
+
+// GetID implements WorkflowRun.
+func (r *testFakeWorkflowRun) GetID() string {
+	return r.ID
+}
+
+// GetRunID implements WorkflowRun.
+func (r *testFakeWorkflowRun) GetRunID() string {
+	return r.RunID
+}
+
+// Get implements WorkflowRun.
+func (r *testFakeWorkflowRun) Get(ctx context.Context, valuePtr interface{}) error {
+	return r.GetWithOptions(ctx, valuePtr, WorkflowRunGetOptions{})
+}
+
+// GetWithOptions implements WorkflowRun. Runs are always followed to the latest one.
+func (r *testFakeWorkflowRun) GetWithOptions(ctx context.Context, valuePtr interface{}, options WorkflowRunGetOptions) error {
+	if err := r.client.wait(ctx, r.done); err != nil {
+		return err
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if valuePtr == nil || r.result == nil {
+		return nil
+	}
+	return r.client.env.dataConverter.FromPayloads(r.result, valuePtr)
+}
+
+func (h *testFakeUpdateHandle) accept() {
+	h.acceptedOnce.Do(func() {
+		close(h.accepted)
+	})
+	h.decide()
+}
+
+func (h *testFakeUpdateHandle) decide() {
+	h.decidedOnce.Do(func() {
+		close(h.decided)
+	})
+}
+
+func (h *testFakeUpdateHandle) reject(err error) {
+	h.complete(nil, err)
+}
+
+func (h *testFakeUpdateHandle) complete(success interface{}, err error) {
+	h.doneOnce.Do(func() {
+		if err != nil {
+			// Round trip the error like a server would.
+			fc := h.client.env.failureConverter
+			h.err = fc.FailureToError(fc.ErrorToFailure(err))
+		} else if success != nil {
+			h.result, h.err = encodeArg(h.client.env.dataConverter, success)
+		}
+		close(h.done)
+	})
+	h.decide()
+}
+
+// WorkflowID implements WorkflowUpdateHandle.
+func (h *testFakeUpdateHandle) WorkflowID() string {
+	return h.workflowID
+}
+
+// RunID implements WorkflowUpdateHandle.
+func (h *testFakeUpdateHandle) RunID() string {
+	return h.runID
+}
+
+// UpdateID implements WorkflowUpdateHandle.
+func (h *testFakeUpdateHandle) UpdateID() string {
+	return h.updateID
+}
+
+// Get implements WorkflowUpdateHandle.
+func (h *testFakeUpdateHandle) Get(ctx context.Context, valuePtr interface{}) error {
+	if err := h.client.wait(ctx, h.done); err != nil {
+		return err
+	}
+	if h.err != nil {
+		return h.err
+	}
+	if valuePtr == nil || h.result == nil {
+		return nil
+	}
+	return h.client.env.dataConverter.FromPayloads(h.result, valuePtr)
+}
+
+// Execute implements WorkflowDefinition.
+func (testFakeClientHostDefinition) Execute(WorkflowEnvironment, *commonpb.Header, *commonpb.Payloads) {
+}
+
+// OnWorkflowTaskStarted implements WorkflowDefinition.
+func (testFakeClientHostDefinition) OnWorkflowTaskStarted(time.Duration) {}
+
+// StackTrace implements WorkflowDefinition.
+func (testFakeClientHostDefinition) StackTrace() string {
+	return ""
+}
+
+// Close implements WorkflowDefinition.
+func (testFakeClientHostDefinition) Close() {}
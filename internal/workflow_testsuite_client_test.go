@@ -0,0 +1,246 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+func fakeClientActivity(_ context.Context, name string) (string, error) {
+	return "hello " + name, nil
+}
+
+func fakeClientWorkflow(ctx Context, name string) (string, error) {
+	count := 0
+	if err := SetQueryHandler(ctx, "count", func() (int, error) {
+		return count, nil
+	}); err != nil {
+		return "", err
+	}
+	if err := SetUpdateHandler(ctx, "add", func(ctx Context, n int) (int, error) {
+		count += n
+		return count, nil
+	}, UpdateHandlerOptions{
+		Validator: func(ctx Context, n int) error {
+			if n < 0 {
+				return errors.New("negative")
+			}
+			return nil
+		},
+	}); err != nil {
+		return "", err
+	}
+	var suffix string
+	GetSignalChannel(ctx, "finish").Receive(ctx, &suffix)
+	if suffix == "fail" {
+		return "", NewApplicationError("failed", "FakeClientFailure", false, nil)
+	}
+	if err := Sleep(ctx, time.Hour); err != nil {
+		return "", err
+	}
+	ctx = WithActivityOptions(ctx, ActivityOptions{StartToCloseTimeout: time.Minute})
+	var greeting string
+	if err := ExecuteActivity(ctx, fakeClientActivity, name).Get(ctx, &greeting); err != nil {
+		return "", err
+	}
+	return greeting + suffix, nil
+}
+
+func newFakeClientForTest(t *testing.T) (*TestWorkflowEnvironment, Client) {
+	env := (&WorkflowTestSuite{}).NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(fakeClientWorkflow)
+	env.RegisterActivity(fakeClientActivity)
+	c := NewFakeClient(env)
+	t.Cleanup(c.Close)
+	return env, c
+}
+
+func TestFakeClient_ExecuteWorkflow(t *testing.T) {
+	t.Parallel()
+	env, c := newFakeClientForTest(t)
+	ctx := context.Background()
+	options := StartWorkflowOptions{ID: "wf", TaskQueue: "tq"}
+
+	run, err := c.ExecuteWorkflow(ctx, options, fakeClientWorkflow, "world")
+	require.NoError(t, err)
+	require.Equal(t, "wf", run.GetID())
+
+	_, err = c.ExecuteWorkflow(ctx, options, fakeClientWorkflow, "world")
+	var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
+	require.ErrorAs(t, err, &alreadyStartedErr)
+
+	handle, err := c.UpdateWorkflow(ctx, UpdateWorkflowOptions{
+		WorkflowID:   "wf",
+		UpdateName:   "add",
+		Args:         []interface{}{2},
+		WaitForStage: WorkflowUpdateStageCompleted,
+	})
+	require.NoError(t, err)
+	var sum int
+	require.NoError(t, handle.Get(ctx, &sum))
+	require.Equal(t, 2, sum)
+
+	_, err = c.UpdateWorkflow(ctx, UpdateWorkflowOptions{
+		WorkflowID:   "wf",
+		UpdateName:   "add",
+		Args:         []interface{}{-1},
+		WaitForStage: WorkflowUpdateStageAccepted,
+	})
+	require.ErrorContains(t, err, "negative")
+
+	value, err := c.QueryWorkflow(ctx, "wf", "", "count")
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, value.Get(&count))
+	require.Equal(t, 2, count)
+
+	// Time is only skipped while waiting for the result.
+	start := env.Now()
+	require.NoError(t, c.SignalWorkflow(ctx, "wf", "", "finish", "!"))
+	require.Equal(t, start, env.Now())
+	var result string
+	require.NoError(t, c.GetWorkflow(ctx, "wf", "").Get(ctx, &result))
+	require.Equal(t, "hello world!", result)
+	require.False(t, env.Now().Before(start.Add(time.Hour)))
+
+	err = c.SignalWorkflow(ctx, "wf", "", "finish", "!")
+	var notFoundErr *serviceerror.NotFound
+	require.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestFakeClient_WorkflowErrors(t *testing.T) {
+	t.Parallel()
+	_, c := newFakeClientForTest(t)
+	ctx := context.Background()
+
+	run, err := c.SignalWithStartWorkflow(ctx, "failed", "finish", "fail", StartWorkflowOptions{TaskQueue: "tq"}, fakeClientWorkflow, "world")
+	require.NoError(t, err)
+	err = run.Get(ctx, nil)
+	var workflowErr *WorkflowExecutionError
+	require.ErrorAs(t, err, &workflowErr)
+	var appErr *ApplicationError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, "FakeClientFailure", appErr.Type())
+
+	run, err = c.ExecuteWorkflow(ctx, StartWorkflowOptions{ID: "canceled", TaskQueue: "tq"}, fakeClientWorkflow, "world")
+	require.NoError(t, err)
+	require.NoError(t, c.SignalWorkflow(ctx, "canceled", "", "finish", "!"))
+	require.NoError(t, c.CancelWorkflow(ctx, "canceled", ""))
+	err = run.Get(ctx, nil)
+	var canceledErr *CanceledError
+	require.ErrorAs(t, err, &canceledErr)
+
+	run, err = c.ExecuteWorkflow(ctx, StartWorkflowOptions{ID: "stuck", TaskQueue: "tq"}, fakeClientWorkflow, "world")
+	require.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, run.Get(timeoutCtx, nil), context.DeadlineExceeded)
+	c.Close()
+	var terminatedErr *TerminatedError
+	require.ErrorAs(t, run.Get(ctx, nil), &terminatedErr)
+}
+
+func fakeClientSignalWithStartWorkflow(ctx Context) (string, error) {
+	// The signal sent with the start is there from the first workflow task.
+	var signal string
+	if !GetSignalChannel(ctx, "signal").ReceiveAsync(&signal) {
+		return "", errors.New("signal not received before the first workflow task")
+	}
+	return signal, nil
+}
+
+func TestFakeClient_SignalWithStartWorkflow(t *testing.T) {
+	t.Parallel()
+	env, c := newFakeClientForTest(t)
+	env.RegisterWorkflow(fakeClientSignalWithStartWorkflow)
+	ctx := context.Background()
+
+	run, err := c.SignalWithStartWorkflow(ctx, "wf", "signal", "hello", StartWorkflowOptions{TaskQueue: "tq"}, fakeClientSignalWithStartWorkflow)
+	require.NoError(t, err)
+	var result string
+	require.NoError(t, run.Get(ctx, &result))
+	require.Equal(t, "hello", result)
+}
+
+func TestFakeClient_RunIDMismatch(t *testing.T) {
+	t.Parallel()
+	_, c := newFakeClientForTest(t)
+	ctx := context.Background()
+
+	run, err := c.ExecuteWorkflow(ctx, StartWorkflowOptions{ID: "wf", TaskQueue: "tq"}, fakeClientWorkflow, "world")
+	require.NoError(t, err)
+	var notFoundErr *serviceerror.NotFound
+	require.ErrorAs(t, c.SignalWorkflow(ctx, "wf", "other-run", "finish", "!"), &notFoundErr)
+	require.ErrorAs(t, c.CancelWorkflow(ctx, "wf", "other-run"), &notFoundErr)
+	require.ErrorAs(t, c.TerminateWorkflow(ctx, "wf", "other-run", "reason"), &notFoundErr)
+	_, err = c.QueryWorkflow(ctx, "wf", "other-run", "count")
+	require.ErrorAs(t, err, &notFoundErr)
+
+	require.NoError(t, c.TerminateWorkflow(ctx, "wf", run.GetRunID(), "reason"))
+	var terminatedErr *TerminatedError
+	require.ErrorAs(t, run.Get(ctx, nil), &terminatedErr)
+}
+
+func TestFakeClient_UpdateIDPerWorkflow(t *testing.T) {
+	t.Parallel()
+	_, c := newFakeClientForTest(t)
+	ctx := context.Background()
+
+	for _, workflowID := range []string{"wf1", "wf2"} {
+		_, err := c.ExecuteWorkflow(ctx, StartWorkflowOptions{ID: workflowID, TaskQueue: "tq"}, fakeClientWorkflow, "world")
+		require.NoError(t, err)
+	}
+	// The same update ID on another workflow is a different update.
+	for i, workflowID := range []string{"wf1", "wf2", "wf1"} {
+		handle, err := c.UpdateWorkflow(ctx, UpdateWorkflowOptions{
+			WorkflowID:   workflowID,
+			UpdateID:     "update",
+			UpdateName:   "add",
+			Args:         []interface{}{i + 1},
+			WaitForStage: WorkflowUpdateStageCompleted,
+		})
+		require.NoError(t, err)
+		require.Equal(t, workflowID, handle.WorkflowID())
+		var sum int
+		require.NoError(t, handle.Get(ctx, &sum))
+		require.Equal(t, map[string]int{"wf1": 1, "wf2": 2}[workflowID], sum)
+	}
+	var sum int
+	require.NoError(t, c.GetWorkflowUpdateHandle(GetWorkflowUpdateHandleOptions{WorkflowID: "wf2", UpdateID: "update"}).Get(ctx, &sum))
+	require.Equal(t, 2, sum)
+	value, err := c.QueryWorkflow(ctx, "wf2", "", "count")
+	require.NoError(t, err)
+	require.NoError(t, value.Get(&sum))
+	require.Equal(t, 2, sum)
+}
+
+func TestFakeClient_Unsupported(t *testing.T) {
+	t.Parallel()
+	_, c := newFakeClientForTest(t)
+	ctx := context.Background()
+
+	var unimplementedErr *serviceerror.Unimplemented
+	_, err := c.DescribeWorkflow(ctx, "wf", "")
+	require.ErrorAs(t, err, &unimplementedErr)
+	_, err = c.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{})
+	require.ErrorAs(t, err, &unimplementedErr)
+	_, err = c.ListWorkflowExecutions(ctx, ClientListWorkflowExecutionsOptions{})
+	require.ErrorAs(t, err, &unimplementedErr)
+	history := c.GetWorkflowHistory(ctx, "wf", "", false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	require.True(t, history.HasNext())
+	_, err = history.Next()
+	require.ErrorAs(t, err, &unimplementedErr)
+	require.False(t, history.HasNext())
+	_, err = c.WorkflowService().GetSystemInfo(ctx, &workflowservice.GetSystemInfoRequest{})
+	require.ErrorAs(t, err, &unimplementedErr)
+	_, err = c.WorkerDeploymentClient().GetHandle("deployment").Describe(ctx, WorkerDeploymentDescribeOptions{})
+	require.ErrorAs(t, err, &unimplementedErr)
+	_, err = c.NewWithStartWorkflowOperation(StartWorkflowOptions{}, fakeClientWorkflow).Get(ctx)
+	require.ErrorAs(t, err, &unimplementedErr)
+}
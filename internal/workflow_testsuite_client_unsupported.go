@@ -0,0 +1,365 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+)
+
+// The methods of a fake client that need a server return a serviceerror.Unimplemented error, as do the methods of
+// the services, deployment clients and handles they return.
+
+type (
+	// testFakeClientConn is the connection of the gRPC service clients of a fake client, failing every call.
+	testFakeClientConn struct{}
+
+	// testFakeHistoryEventIterator is the history iterator of a fake client, failing on the first event.
+	testFakeHistoryEventIterator struct {
+		done bool
+	}
+
+	// testFakeActivityHandle is the handle of a standalone activity of a fake client, which can't be used.
+	testFakeActivityHandle struct {
+		options ClientGetActivityHandleOptions
+	}
+
+	// testFakeDeploymentClient is the deployment client of a fake client, which can't be used.
+	testFakeDeploymentClient struct{}
+
+	// testFakeWorkerDeploymentClient is the worker deployment client of a fake client, which can't be used.
+	testFakeWorkerDeploymentClient struct{}
+
+	// testFakeWorkerDeploymentHandle is a worker deployment handle of a fake client, which can't be used.
+	testFakeWorkerDeploymentHandle struct{}
+)
+
+func errFakeClientUnsupported(method string) error {
+	return serviceerror.NewUnimplemented(fmt.Sprintf("%v is not supported by the fake client", method))
+}
+
+// DescribeWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) DescribeWorkflow(ctx context.Context, workflowID string, runID string) (*WorkflowExecutionDescription, error) {
+	return nil, errFakeClientUnsupported("DescribeWorkflow")
+}
+
+// CompleteActivity implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CompleteActivity(ctx context.Context, taskToken []byte, result interface{}, err error) error {
+	return errFakeClientUnsupported("CompleteActivity")
+}
+
+// CompleteActivityWithOptions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CompleteActivityWithOptions(ctx context.Context, opts CompleteActivityOptions) error {
+	return errFakeClientUnsupported("CompleteActivityWithOptions")
+}
+
+// CompleteActivityByID implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CompleteActivityByID(ctx context.Context, namespace string, workflowID string, runID string, activityID string, result interface{}, err error) error {
+	return errFakeClientUnsupported("CompleteActivityByID")
+}
+
+// CompleteActivityByIDWithOptions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CompleteActivityByIDWithOptions(ctx context.Context, opts CompleteActivityByIDOptions) error {
+	return errFakeClientUnsupported("CompleteActivityByIDWithOptions")
+}
+
+// CompleteActivityByActivityID implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CompleteActivityByActivityID(ctx context.Context, namespace string, activityID string, activityRunID string, result interface{}, err error) error {
+	return errFakeClientUnsupported("CompleteActivityByActivityID")
+}
+
+// CompleteActivityByActivityIDWithOptions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CompleteActivityByActivityIDWithOptions(ctx context.Context, opts CompleteActivityByActivityIDOptions) error {
+	return errFakeClientUnsupported("CompleteActivityByActivityIDWithOptions")
+}
+
+// CountWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CountWorkflow(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) (*workflowservice.CountWorkflowExecutionsResponse, error) {
+	return nil, errFakeClientUnsupported("CountWorkflow")
+}
+
+// DescribeTaskQueue implements Client. It is not supported by the fake client.
+func (c *testFakeClient) DescribeTaskQueue(ctx context.Context, taskqueue string, taskqueueType enumspb.TaskQueueType) (*workflowservice.DescribeTaskQueueResponse, error) {
+	return nil, errFakeClientUnsupported("DescribeTaskQueue")
+}
+
+// DescribeTaskQueueEnhanced implements Client. It is not supported by the fake client.
+func (c *testFakeClient) DescribeTaskQueueEnhanced(ctx context.Context, options DescribeTaskQueueEnhancedOptions) (TaskQueueDescription, error) {
+	return TaskQueueDescription{}, errFakeClientUnsupported("DescribeTaskQueueEnhanced")
+}
+
+// DescribeWorkflowExecution implements Client. It is not supported by the fake client.
+func (c *testFakeClient) DescribeWorkflowExecution(ctx context.Context, workflowID string, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	return nil, errFakeClientUnsupported("DescribeWorkflowExecution")
+}
+
+// GetSearchAttributes implements Client. It is not supported by the fake client.
+func (c *testFakeClient) GetSearchAttributes(ctx context.Context) (*workflowservice.GetSearchAttributesResponse, error) {
+	return nil, errFakeClientUnsupported("GetSearchAttributes")
+}
+
+// GetWorkerBuildIdCompatibility implements Client. It is not supported by the fake client.
+func (c *testFakeClient) GetWorkerBuildIdCompatibility(ctx context.Context, options *GetWorkerBuildIdCompatibilityOptions) (*WorkerBuildIDVersionSets, error) {
+	return nil, errFakeClientUnsupported("GetWorkerBuildIdCompatibility")
+}
+
+// GetWorkerTaskReachability implements Client. It is not supported by the fake client.
+func (c *testFakeClient) GetWorkerTaskReachability(ctx context.Context, options *GetWorkerTaskReachabilityOptions) (*WorkerTaskReachability, error) {
+	return nil, errFakeClientUnsupported("GetWorkerTaskReachability")
+}
+
+// GetWorkerVersioningRules implements Client. It is not supported by the fake client.
+func (c *testFakeClient) GetWorkerVersioningRules(ctx context.Context, options GetWorkerVersioningOptions) (*WorkerVersioningRules, error) {
+	return nil, errFakeClientUnsupported("GetWorkerVersioningRules")
+}
+
+// ListArchivedWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListArchivedWorkflow(ctx context.Context, request *workflowservice.ListArchivedWorkflowExecutionsRequest) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error) {
+	return nil, errFakeClientUnsupported("ListArchivedWorkflow")
+}
+
+// ListWorkflowExecutions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	return ClientListWorkflowExecutionsResult{}, errFakeClientUnsupported("ListWorkflowExecutions")
+}
+
+// ListArchivedWorkflowExecutions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListArchivedWorkflowExecutions(ctx context.Context, options ClientListWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	return ClientListWorkflowExecutionsResult{}, errFakeClientUnsupported("ListArchivedWorkflowExecutions")
+}
+
+// ListClosedWorkflowExecutions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListClosedWorkflowExecutions(ctx context.Context, options ClientListClosedWorkflowExecutionsOptions) (ClientListWorkflowExecutionsResult, error) {
+	return ClientListWorkflowExecutionsResult{}, errFakeClientUnsupported("ListClosedWorkflowExecutions")
+}
+
+// ListClosedWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListClosedWorkflow(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
+	return nil, errFakeClientUnsupported("ListClosedWorkflow")
+}
+
+// ListOpenWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListOpenWorkflow(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+	return nil, errFakeClientUnsupported("ListOpenWorkflow")
+}
+
+// ListWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	return nil, errFakeClientUnsupported("ListWorkflow")
+}
+
+// QueryWorkflowWithOptions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) QueryWorkflowWithOptions(ctx context.Context, request *QueryWorkflowWithOptionsRequest) (*QueryWorkflowWithOptionsResponse, error) {
+	return nil, errFakeClientUnsupported("QueryWorkflowWithOptions")
+}
+
+// RecordActivityHeartbeat implements Client. It is not supported by the fake client.
+func (c *testFakeClient) RecordActivityHeartbeat(ctx context.Context, taskToken []byte, details ...interface{}) error {
+	return errFakeClientUnsupported("RecordActivityHeartbeat")
+}
+
+// RecordActivityHeartbeatWithOptions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) RecordActivityHeartbeatWithOptions(ctx context.Context, opts RecordActivityHeartbeatOptions) error {
+	return errFakeClientUnsupported("RecordActivityHeartbeatWithOptions")
+}
+
+// RecordActivityHeartbeatByID implements Client. It is not supported by the fake client.
+func (c *testFakeClient) RecordActivityHeartbeatByID(ctx context.Context, namespace string, workflowID string, runID string, activityID string, details ...interface{}) error {
+	return errFakeClientUnsupported("RecordActivityHeartbeatByID")
+}
+
+// RecordActivityHeartbeatByIDWithOptions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) RecordActivityHeartbeatByIDWithOptions(ctx context.Context, opts RecordActivityHeartbeatByIDOptions) error {
+	return errFakeClientUnsupported("RecordActivityHeartbeatByIDWithOptions")
+}
+
+// ResetWorkflowExecution implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error) {
+	return nil, errFakeClientUnsupported("ResetWorkflowExecution")
+}
+
+// ScanWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ScanWorkflow(ctx context.Context, request *workflowservice.ScanWorkflowExecutionsRequest) (*workflowservice.ScanWorkflowExecutionsResponse, error) {
+	return nil, errFakeClientUnsupported("ScanWorkflow")
+}
+
+// UpdateWithStartWorkflow implements Client. It is not supported by the fake client.
+func (c *testFakeClient) UpdateWithStartWorkflow(ctx context.Context, options UpdateWithStartWorkflowOptions) (WorkflowUpdateHandle, error) {
+	return nil, errFakeClientUnsupported("UpdateWithStartWorkflow")
+}
+
+// UpdateWorkerBuildIdCompatibility implements Client. It is not supported by the fake client.
+func (c *testFakeClient) UpdateWorkerBuildIdCompatibility(ctx context.Context, options *UpdateWorkerBuildIdCompatibilityOptions) error {
+	return errFakeClientUnsupported("UpdateWorkerBuildIdCompatibility")
+}
+
+// UpdateWorkerVersioningRules implements Client. It is not supported by the fake client.
+func (c *testFakeClient) UpdateWorkerVersioningRules(ctx context.Context, options UpdateWorkerVersioningRulesOptions) (*WorkerVersioningRules, error) {
+	return nil, errFakeClientUnsupported("UpdateWorkerVersioningRules")
+}
+
+// ExecuteActivity implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ExecuteActivity(ctx context.Context, options ClientStartActivityOptions, activity any, args ...any) (ClientActivityHandle, error) {
+	return nil, errFakeClientUnsupported("ExecuteActivity")
+}
+
+// ListActivities implements Client. It is not supported by the fake client.
+func (c *testFakeClient) ListActivities(ctx context.Context, options ClientListActivitiesOptions) (ClientListActivitiesResult, error) {
+	return ClientListActivitiesResult{}, errFakeClientUnsupported("ListActivities")
+}
+
+// CountActivities implements Client. It is not supported by the fake client.
+func (c *testFakeClient) CountActivities(ctx context.Context, options ClientCountActivitiesOptions) (*ClientCountActivitiesResult, error) {
+	return nil, errFakeClientUnsupported("CountActivities")
+}
+
+// UpdateWorkflowExecutionOptions implements Client. It is not supported by the fake client.
+func (c *testFakeClient) UpdateWorkflowExecutionOptions(ctx context.Context, options UpdateWorkflowExecutionOptionsRequest) (WorkflowExecutionOptions, error) {
+	return WorkflowExecutionOptions{}, errFakeClientUnsupported("UpdateWorkflowExecutionOptions")
+}
+
+// NewWithStartWorkflowOperation implements Client. UpdateWithStartWorkflow is not supported by the fake client, so
+// the operation fails once used.
+func (c *testFakeClient) NewWithStartWorkflowOperation(options StartWorkflowOptions, workflow interface{}, args ...interface{}) WithStartWorkflowOperation {
+	op := &withStartWorkflowOperationImpl{doneCh: make(chan struct{})}
+	op.set(nil, errFakeClientUnsupported("UpdateWithStartWorkflow"))
+	return op
+}
+
+// GetWorkflowHistory implements Client. It is not supported by the fake client, the iterator fails on the first
+// event.
+func (c *testFakeClient) GetWorkflowHistory(ctx context.Context, workflowID string, runID string, isLongPoll bool, filterType enumspb.HistoryEventFilterType) HistoryEventIterator {
+	return &testFakeHistoryEventIterator{}
+}
+
+// GetActivityHandle implements Client. Standalone activities are not supported by the fake client, the methods of
+// the handle fail.
+func (c *testFakeClient) GetActivityHandle(options ClientGetActivityHandleOptions) ClientActivityHandle {
+	return &testFakeActivityHandle{options: options}
+}
+
+// WorkflowService implements Client. Its calls are not supported by the fake client.
+func (c *testFakeClient) WorkflowService() workflowservice.WorkflowServiceClient {
+	return workflowservice.NewWorkflowServiceClient(testFakeClientConn{})
+}
+
+// OperatorService implements Client. Its calls are not supported by the fake client.
+func (c *testFakeClient) OperatorService() operatorservice.OperatorServiceClient {
+	return operatorservice.NewOperatorServiceClient(testFakeClientConn{})
+}
+
+// DeploymentClient implements Client. Its calls are not supported by the fake client.
+func (c *testFakeClient) DeploymentClient() DeploymentClient {
+	return testFakeDeploymentClient{}
+}
+
+// WorkerDeploymentClient implements Client. Its calls are not supported by the fake client.
+func (c *testFakeClient) WorkerDeploymentClient() WorkerDeploymentClient {
+	return testFakeWorkerDeploymentClient{}
+}
+
+func (testFakeClientConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	return errFakeClientUnsupported(method)
+}
+
+func (testFakeClientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errFakeClientUnsupported(method)
+}
+
+func (it *testFakeHistoryEventIterator) HasNext() bool {
+	return !it.done
+}
+
+func (it *testFakeHistoryEventIterator) Next() (*historypb.HistoryEvent, error) {
+	it.done = true
+	return nil, errFakeClientUnsupported("GetWorkflowHistory")
+}
+
+func (h *testFakeActivityHandle) GetID() string {
+	return h.options.ActivityID
+}
+
+func (h *testFakeActivityHandle) GetRunID() string {
+	return h.options.RunID
+}
+
+func (h *testFakeActivityHandle) Get(ctx context.Context, valuePtr any) error {
+	return errFakeClientUnsupported("GetActivityHandle")
+}
+
+func (h *testFakeActivityHandle) Describe(ctx context.Context, options ClientDescribeActivityOptions) (*ClientActivityExecutionDescription, error) {
+	return nil, errFakeClientUnsupported("GetActivityHandle")
+}
+
+func (h *testFakeActivityHandle) Cancel(ctx context.Context, options ClientCancelActivityOptions) error {
+	return errFakeClientUnsupported("GetActivityHandle")
+}
+
+func (h *testFakeActivityHandle) Terminate(ctx context.Context, options ClientTerminateActivityOptions) error {
+	return errFakeClientUnsupported("GetActivityHandle")
+}
+
+func (testFakeDeploymentClient) Describe(ctx context.Context, options DeploymentDescribeOptions) (DeploymentDescription, error) {
+	return DeploymentDescription{}, errFakeClientUnsupported("DeploymentClient")
+}
+
+func (testFakeDeploymentClient) List(ctx context.Context, options DeploymentListOptions) (DeploymentListIterator, error) {
+	return nil, errFakeClientUnsupported("DeploymentClient")
+}
+
+func (testFakeDeploymentClient) GetReachability(ctx context.Context, options DeploymentGetReachabilityOptions) (DeploymentReachabilityInfo, error) {
+	return DeploymentReachabilityInfo{}, errFakeClientUnsupported("DeploymentClient")
+}
+
+func (testFakeDeploymentClient) GetCurrent(ctx context.Context, options DeploymentGetCurrentOptions) (DeploymentGetCurrentResponse, error) {
+	return DeploymentGetCurrentResponse{}, errFakeClientUnsupported("DeploymentClient")
+}
+
+func (testFakeDeploymentClient) SetCurrent(ctx context.Context, options DeploymentSetCurrentOptions) (DeploymentSetCurrentResponse, error) {
+	return DeploymentSetCurrentResponse{}, errFakeClientUnsupported("DeploymentClient")
+}
+
+func (testFakeWorkerDeploymentClient) List(ctx context.Context, options WorkerDeploymentListOptions) (WorkerDeploymentListIterator, error) {
+	return nil, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentClient) GetHandle(name string) WorkerDeploymentHandle {
+	return testFakeWorkerDeploymentHandle{}
+}
+
+func (testFakeWorkerDeploymentClient) Delete(ctx context.Context, options WorkerDeploymentDeleteOptions) (WorkerDeploymentDeleteResponse, error) {
+	return WorkerDeploymentDeleteResponse{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentHandle) Describe(ctx context.Context, options WorkerDeploymentDescribeOptions) (WorkerDeploymentDescribeResponse, error) {
+	return WorkerDeploymentDescribeResponse{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentHandle) SetCurrentVersion(ctx context.Context, options WorkerDeploymentSetCurrentVersionOptions) (WorkerDeploymentSetCurrentVersionResponse, error) {
+	return WorkerDeploymentSetCurrentVersionResponse{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentHandle) SetRampingVersion(ctx context.Context, options WorkerDeploymentSetRampingVersionOptions) (WorkerDeploymentSetRampingVersionResponse, error) {
+	return WorkerDeploymentSetRampingVersionResponse{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentHandle) SetManagerIdentity(ctx context.Context, options WorkerDeploymentSetManagerIdentityOptions) (WorkerDeploymentSetManagerIdentityResponse, error) {
+	return WorkerDeploymentSetManagerIdentityResponse{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentHandle) DescribeVersion(ctx context.Context, options WorkerDeploymentDescribeVersionOptions) (WorkerDeploymentVersionDescription, error) {
+	return WorkerDeploymentVersionDescription{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentHandle) DeleteVersion(ctx context.Context, options WorkerDeploymentDeleteVersionOptions) (WorkerDeploymentDeleteVersionResponse, error) {
+	return WorkerDeploymentDeleteVersionResponse{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
+
+func (testFakeWorkerDeploymentHandle) UpdateVersionMetadata(ctx context.Context, options WorkerDeploymentUpdateVersionMetadataOptions) (WorkerDeploymentUpdateVersionMetadataResponse, error) {
+	return WorkerDeploymentUpdateVersionMetadataResponse{}, errFakeClientUnsupported("WorkerDeploymentClient")
+}
@@ -0,0 +1,581 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/sdk/converter"
+)
+
+const (
+	// The search attributes the server sets on workflows started by a schedule.
+	scheduledStartTimeSearchAttribute = "TemporalScheduledStartTime"
+	scheduledByIDSearchAttribute      = "TemporalScheduledById"
+
+	// testFakeScheduleActionCount is how many recent and future actions a schedule description has, like on the
+	// server.
+	testFakeScheduleActionCount = 10
+)
+
+type (
+	// testFakeScheduleClient is the [ScheduleClient] of a fake client. Schedules take their actions as the simulated
+	// time of the environment passes.
+	testFakeScheduleClient struct {
+		client *testFakeClient
+	}
+
+	// testFakeScheduleHandle is the [ScheduleHandle] of a schedule of a fake client.
+	testFakeScheduleHandle struct {
+		client *testFakeClient
+		id     string
+	}
+
+	// testFakeScheduleListIterator is the [ScheduleListIterator] of a fake client.
+	testFakeScheduleListIterator struct {
+		entries []*ScheduleListEntry
+	}
+
+	// testFakeSchedule is a schedule of a fake client. It is only accessed in the main loop.
+	testFakeSchedule struct {
+		id               string
+		action           ScheduleWorkflowAction
+		header           *commonpb.Header
+		spec             ScheduleSpec
+		evaluator        *ScheduleSpecEvaluator
+		policy           SchedulePolicies
+		state            ScheduleState
+		memo             *commonpb.Memo
+		searchAttributes SearchAttributes
+		info             ScheduleInfo
+		deleted          bool
+
+		// processedUntil is the time up to which the spec has been processed.
+		processedUntil time.Time
+		timer          *TimerID
+		running        []*testFakeWorkflowRun
+		// buffered are the actions waiting for the running workflows to complete.
+		buffered []time.Time
+	}
+)
+
+// ScheduleClient implements Client.
+func (c *testFakeClient) ScheduleClient() ScheduleClient {
+	return &testFakeScheduleClient{client: c}
+}
+
+// Create implements ScheduleClient.
+func (sc *testFakeScheduleClient) Create(ctx context.Context, options ScheduleOptions) (ScheduleHandle, error) {
+	c := sc.client
+	if options.ID == "" {
+		return nil, serviceerror.NewInvalidArgument("missing schedule ID")
+	}
+	schedule := &testFakeSchedule{
+		id: options.ID,
+		policy: SchedulePolicies{
+			Overlap:        options.Overlap,
+			CatchupWindow:  options.CatchupWindow,
+			PauseOnFailure: options.PauseOnFailure,
+		},
+		state: ScheduleState{
+			Note:             options.Note,
+			Paused:           options.Paused,
+			LimitedActions:   options.RemainingActions != 0,
+			RemainingActions: options.RemainingActions,
+		},
+		searchAttributes: options.TypedSearchAttributes,
+	}
+	if err := c.setScheduleAction(ctx, schedule, options.Action); err != nil {
+		return nil, err
+	}
+	if err := schedule.setSpec(options.Spec); err != nil {
+		return nil, err
+	}
+	memo, err := encodeScheduleWorkflowMemo(c.env.dataConverter, options.Memo)
+	if err != nil {
+		return nil, err
+	}
+	schedule.memo = memo
+
+	if doErr := c.do(func() {
+		if _, ok := c.schedules[options.ID]; ok {
+			err = ErrScheduleAlreadyRunning
+			return
+		}
+		now := c.env.Now().UTC()
+		schedule.info.CreatedAt = now
+		schedule.processedUntil = now
+		c.schedules[options.ID] = schedule
+		for _, backfill := range options.ScheduleBackfill {
+			c.backfillSchedule(schedule, backfill)
+		}
+		if options.TriggerImmediately {
+			c.takeScheduleAction(schedule, now, enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED)
+		}
+		c.scheduleNextAction(schedule)
+	}); doErr != nil {
+		return nil, doErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &testFakeScheduleHandle{client: c, id: options.ID}, nil
+}
+
+// List implements ScheduleClient. Queries are not supported.
+func (sc *testFakeScheduleClient) List(ctx context.Context, options ScheduleListOptions) (ScheduleListIterator, error) {
+	if options.Query != "" || options.TypedQuery.String() != "" {
+		return nil, serviceerror.NewInvalidArgument("schedule list queries are not supported by the fake client")
+	}
+	iter := &testFakeScheduleListIterator{}
+	if err := sc.client.do(func() {
+		for _, schedule := range sc.client.schedules {
+			description := sc.client.describeSchedule(schedule)
+			workflowType, _ := getWorkflowFunctionName(sc.client.env.registry, schedule.action.Workflow)
+			iter.entries = append(iter.entries, &ScheduleListEntry{
+				ID:               schedule.id,
+				Spec:             description.Schedule.Spec,
+				Note:             schedule.state.Note,
+				Paused:           schedule.state.Paused,
+				WorkflowType:     WorkflowType{Name: workflowType},
+				RecentActions:    description.Info.RecentActions,
+				NextActionTimes:  description.Info.NextActionTimes,
+				Memo:             description.Memo,
+				SearchAttributes: description.SearchAttributes,
+			})
+		}
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(iter.entries, func(i, j int) bool { return iter.entries[i].ID < iter.entries[j].ID })
+	return iter, nil
+}
+
+// GetHandle implements ScheduleClient.
+func (sc *testFakeScheduleClient) GetHandle(ctx context.Context, scheduleID string) ScheduleHandle {
+	return &testFakeScheduleHandle{client: sc.client, id: scheduleID}
+}
+
+// setScheduleAction validates the action and sets it on the schedule, with the header of the context the action is
+// set with.
+func (c *testFakeClient) setScheduleAction(ctx context.Context, schedule *testFakeSchedule, action ScheduleAction) error {
+	workflowAction, ok := action.(*ScheduleWorkflowAction)
+	if !ok {
+		return serviceerror.NewInvalidArgument("schedule must have a workflow action")
+	}
+	if workflowAction.TaskQueue == "" {
+		return serviceerror.NewInvalidArgument("missing task queue name")
+	}
+	if _, err := getWorkflowFunctionName(c.env.registry, workflowAction.Workflow); err != nil {
+		return err
+	}
+	header, err := headerPropagated(contextWithNewHeader(ctx), c.env.contextPropagators)
+	if err != nil {
+		return err
+	}
+	schedule.action = *workflowAction
+	if schedule.action.ID == "" {
+		schedule.action.ID = uuid.NewString()
+	}
+	schedule.header = header
+	return nil
+}
+
+func (s *testFakeSchedule) setSpec(spec ScheduleSpec) error {
+	evaluator, err := NewScheduleSpecEvaluator(spec)
+	if err != nil {
+		return serviceerror.NewInvalidArgument(err.Error())
+	}
+	s.spec = spec
+	s.evaluator = evaluator
+	return nil
+}
+
+// scheduleNextAction sets a timer for the next time matched by the spec of the schedule.
+func (c *testFakeClient) scheduleNextAction(s *testFakeSchedule) {
+	if s.timer != nil {
+		c.env.RequestCancelTimer(*s.timer)
+		s.timer = nil
+	}
+	if s.deleted {
+		return
+	}
+	next, ok := s.evaluator.Next(s.processedUntil)
+	if !ok {
+		return
+	}
+	var timer *TimerID
+	timer = c.env.newTimer(next.Sub(c.env.Now()), TimerOptions{}, func(_ *commonpb.Payloads, err error) {
+		if err != nil || s.timer != timer {
+			return
+		}
+		s.timer = nil
+		s.processedUntil = next
+		if s.canTakeScheduledAction() {
+			c.takeScheduleAction(s, next, enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED)
+		}
+		c.scheduleNextAction(s)
+	}, false)
+	s.timer = timer
+}
+
+// canTakeScheduledAction reports whether the schedule takes the action of a time matched by its spec, and counts the
+// action if so. Triggered and backfilled actions are always taken.
+func (s *testFakeSchedule) canTakeScheduledAction() bool {
+	if s.state.Paused {
+		return false
+	}
+	if s.state.LimitedActions {
+		if s.state.RemainingActions <= 0 {
+			return false
+		}
+		s.state.RemainingActions--
+	}
+	return true
+}
+
+func (c *testFakeClient) backfillSchedule(s *testFakeSchedule, backfill ScheduleBackfill) {
+	// The start and end of a backfill are inclusive.
+	after := backfill.Start.Add(-time.Nanosecond)
+	for {
+		next, ok := s.evaluator.Next(after)
+		if !ok || next.After(backfill.End) {
+			return
+		}
+		c.takeScheduleAction(s, next, backfill.Overlap)
+		after = next
+	}
+}
+
+// takeScheduleAction starts the workflow of the schedule for the given nominal time, unless the overlap policy
+// delays or skips it.
+func (c *testFakeClient) takeScheduleAction(s *testFakeSchedule, nominalTime time.Time, overlap enumspb.ScheduleOverlapPolicy) {
+	if overlap == enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED {
+		overlap = s.policy.Overlap
+	}
+	overlap = scheduleOverlapPolicy(overlap)
+	if len(s.running) == 0 || overlap == enumspb.SCHEDULE_OVERLAP_POLICY_ALLOW_ALL {
+		c.startScheduledWorkflow(s, nominalTime)
+		return
+	}
+	switch overlap {
+	case enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE:
+		if len(s.buffered) == 0 {
+			s.buffered = append(s.buffered, nominalTime)
+		} else {
+			s.info.NumActionsSkippedOverlap++
+		}
+	case enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL:
+		s.buffered = append(s.buffered, nominalTime)
+	case enumspb.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER, enumspb.SCHEDULE_OVERLAP_POLICY_TERMINATE_OTHER:
+		// Only the latest action waits for the running workflows to be canceled or terminated.
+		s.buffered = []time.Time{nominalTime}
+		for _, run := range append([]*testFakeWorkflowRun(nil), s.running...) {
+			handle, ok := c.env.runningWorkflows[run.ID]
+			if !ok || handle.handled {
+				continue
+			}
+			if overlap == enumspb.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER {
+				handle.env.cancelWorkflow(func(result *commonpb.Payloads, err error) {})
+			} else {
+				handle.env.Complete(nil, newTerminatedError())
+			}
+		}
+	default:
+		s.info.NumActionsSkippedOverlap++
+	}
+}
+
+func (c *testFakeClient) startScheduledWorkflow(s *testFakeSchedule, nominalTime time.Time) {
+	action := s.action
+	// Like the server, the ID of the action is suffixed with the nominal time so that each start has its own ID.
+	workflowID := fmt.Sprintf("%s-%s", action.ID, nominalTime.UTC().Truncate(time.Second).Format(time.RFC3339))
+	run, err := func() (*testFakeWorkflowRun, error) {
+		workflowType, err := getWorkflowFunctionName(c.env.registry, action.Workflow)
+		if err != nil {
+			return nil, err
+		}
+		dc := converter.WithDataConverterSerializationContext(c.env.dataConverter, converter.WorkflowSerializationContext{
+			Namespace:  c.env.workflowInfo.Namespace,
+			WorkflowID: action.ID,
+		})
+		input, err := encodeScheduleWorklowArgs(dc, action.Args)
+		if err != nil {
+			return nil, err
+		}
+		searchAttributes, err := s.workflowSearchAttributes(nominalTime)
+		if err != nil {
+			return nil, err
+		}
+		return c.startWorkflowInLoop(StartWorkflowOptions{
+			ID:                       workflowID,
+			TaskQueue:                action.TaskQueue,
+			WorkflowExecutionTimeout: action.WorkflowExecutionTimeout,
+			WorkflowRunTimeout:       action.WorkflowRunTimeout,
+			WorkflowTaskTimeout:      action.WorkflowTaskTimeout,
+			WorkflowIDReusePolicy:    enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+			RetryPolicy:              action.RetryPolicy,
+			Memo:                     action.Memo,
+			SearchAttributes:         searchAttributes,
+			Priority:                 action.Priority,
+		}, WorkflowType{Name: workflowType}, input, s.header, dc)
+	}()
+	if err != nil {
+		c.env.logger.Warn("Failed to start scheduled workflow", tagWorkflowID, workflowID, tagError, err)
+		return
+	}
+
+	s.info.NumActions++
+	s.info.RecentActions = append(s.info.RecentActions, ScheduleActionResult{
+		ScheduleTime: nominalTime,
+		ActualTime:   c.env.Now().UTC(),
+		StartWorkflowResult: &ScheduleWorkflowExecution{
+			WorkflowID:          run.ID,
+			FirstExecutionRunID: run.RunID,
+		},
+	})
+	if len(s.info.RecentActions) > testFakeScheduleActionCount {
+		s.info.RecentActions = s.info.RecentActions[len(s.info.RecentActions)-testFakeScheduleActionCount:]
+	}
+	s.running = append(s.running, run)
+	run.onComplete = func() {
+		c.onScheduledWorkflowCompleted(s, run)
+	}
+}
+
+// workflowSearchAttributes returns the search attributes of the workflow started for the given nominal time.
+func (s *testFakeSchedule) workflowSearchAttributes(nominalTime time.Time) (map[string]interface{}, error) {
+	typed, err := serializeTypedSearchAttributes(NewSearchAttributes(
+		s.action.TypedSearchAttributes.Copy(),
+		NewSearchAttributeKeyTime(scheduledStartTimeSearchAttribute).ValueSet(nominalTime),
+		NewSearchAttributeKeyKeyword(scheduledByIDSearchAttribute).ValueSet(s.id),
+	).GetUntypedValues())
+	if err != nil {
+		return nil, err
+	}
+	searchAttributes := make(map[string]interface{}, len(s.action.UntypedSearchAttributes)+len(typed.GetIndexedFields()))
+	for name, payload := range s.action.UntypedSearchAttributes {
+		searchAttributes[name] = payload
+	}
+	for name, payload := range typed.GetIndexedFields() {
+		searchAttributes[name] = payload
+	}
+	return searchAttributes, nil
+}
+
+func (c *testFakeClient) onScheduledWorkflowCompleted(s *testFakeSchedule, run *testFakeWorkflowRun) {
+	for i, running := range s.running {
+		if running == run {
+			s.running = append(s.running[:i], s.running[i+1:]...)
+			break
+		}
+	}
+	if s.deleted {
+		return
+	}
+	var canceledErr *CanceledError
+	var terminatedErr *TerminatedError
+	if run.err != nil && s.policy.PauseOnFailure && !errors.As(run.err, &canceledErr) && !errors.As(run.err, &terminatedErr) {
+		s.state.Paused = true
+		s.state.Note = fmt.Sprintf("paused due to workflow failure: %s: %v", run.ID, errors.Unwrap(run.err))
+	}
+	for len(s.running) == 0 && len(s.buffered) > 0 {
+		nominalTime := s.buffered[0]
+		s.buffered = s.buffered[1:]
+		c.startScheduledWorkflow(s, nominalTime)
+	}
+}
+
+func (c *testFakeClient) deleteSchedule(s *testFakeSchedule) {
+	s.deleted = true
+	s.buffered = nil
+	c.scheduleNextAction(s)
+	delete(c.schedules, s.id)
+}
+
+func (c *testFakeClient) describeSchedule(s *testFakeSchedule) *ScheduleDescription {
+	action := s.action
+	spec := s.spec
+	policy := s.policy
+	state := s.state
+	info := s.info
+	info.RecentActions = append([]ScheduleActionResult(nil), s.info.RecentActions...)
+	info.RunningWorkflows = nil
+	for _, run := range s.running {
+		info.RunningWorkflows = append(info.RunningWorkflows, ScheduleWorkflowExecution{
+			WorkflowID:          run.ID,
+			FirstExecutionRunID: run.RunID,
+		})
+	}
+	count := testFakeScheduleActionCount
+	if state.LimitedActions && state.RemainingActions < count {
+		count = state.RemainingActions
+	}
+	if !state.Paused {
+		info.NextActionTimes = s.evaluator.NextTimes(s.processedUntil, count)
+	}
+	// The search attributes were validated when they were set.
+	searchAttributes, _ := serializeTypedSearchAttributes(s.searchAttributes.GetUntypedValues())
+	return &ScheduleDescription{
+		Schedule: Schedule{
+			Action: &action,
+			Spec:   &spec,
+			Policy: &policy,
+			State:  &state,
+		},
+		Info:                  info,
+		Memo:                  s.memo,
+		SearchAttributes:      searchAttributes,
+		TypedSearchAttributes: s.searchAttributes,
+	}
+}
+
+// withSchedule calls f in the main loop with the schedule of the handle.
+func (h *testFakeScheduleHandle) withSchedule(f func(s *testFakeSchedule)) error {
+	var err error
+	if doErr := h.client.do(func() {
+		s, ok := h.client.schedules[h.id]
+		if !ok {
+			err = serviceerror.NewNotFound(fmt.Sprintf("schedule %v not found", h.id))
+			return
+		}
+		f(s)
+	}); doErr != nil {
+		return doErr
+	}
+	return err
+}
+
+// GetID implements ScheduleHandle.
+func (h *testFakeScheduleHandle) GetID() string {
+	return h.id
+}
+
+// Delete implements ScheduleHandle.
+func (h *testFakeScheduleHandle) Delete(ctx context.Context) error {
+	return h.withSchedule(h.client.deleteSchedule)
+}
+
+// Backfill implements ScheduleHandle.
+func (h *testFakeScheduleHandle) Backfill(ctx context.Context, options ScheduleBackfillOptions) error {
+	return h.withSchedule(func(s *testFakeSchedule) {
+		for _, backfill := range options.Backfill {
+			h.client.backfillSchedule(s, backfill)
+		}
+	})
+}
+
+// Update implements ScheduleHandle.
+func (h *testFakeScheduleHandle) Update(ctx context.Context, options ScheduleUpdateOptions) error {
+	description, err := h.Describe(ctx)
+	if err != nil {
+		return err
+	}
+	update, err := options.DoUpdate(ScheduleUpdateInput{Description: *description})
+	if err != nil {
+		if errors.Is(err, ErrSkipScheduleUpdate) {
+			return nil
+		}
+		return err
+	}
+	if update.Schedule == nil {
+		return serviceerror.NewInvalidArgument("missing schedule")
+	}
+	updated := &testFakeSchedule{}
+	if err := h.client.setScheduleAction(ctx, updated, update.Schedule.Action); err != nil {
+		return err
+	}
+	spec := ScheduleSpec{}
+	if update.Schedule.Spec != nil {
+		spec = *update.Schedule.Spec
+	}
+	if err := updated.setSpec(spec); err != nil {
+		return err
+	}
+	return h.withSchedule(func(s *testFakeSchedule) {
+		s.action, s.header = updated.action, updated.header
+		s.spec, s.evaluator = updated.spec, updated.evaluator
+		s.policy = SchedulePolicies{}
+		if update.Schedule.Policy != nil {
+			s.policy = *update.Schedule.Policy
+		}
+		s.state = ScheduleState{}
+		if update.Schedule.State != nil {
+			s.state = *update.Schedule.State
+		}
+		if update.TypedSearchAttributes != nil {
+			s.searchAttributes = *update.TypedSearchAttributes
+		}
+		now := h.client.env.Now().UTC()
+		s.info.LastUpdateAt = now
+		// Times before the update are not matched by the new spec.
+		s.processedUntil = now
+		h.client.scheduleNextAction(s)
+	})
+}
+
+// Describe implements ScheduleHandle.
+func (h *testFakeScheduleHandle) Describe(ctx context.Context) (*ScheduleDescription, error) {
+	var description *ScheduleDescription
+	if err := h.withSchedule(func(s *testFakeSchedule) {
+		description = h.client.describeSchedule(s)
+	}); err != nil {
+		return nil, err
+	}
+	return description, nil
+}
+
+// Trigger implements ScheduleHandle.
+func (h *testFakeScheduleHandle) Trigger(ctx context.Context, options ScheduleTriggerOptions) error {
+	return h.withSchedule(func(s *testFakeSchedule) {
+		h.client.takeScheduleAction(s, h.client.env.Now().UTC(), options.Overlap)
+	})
+}
+
+// Pause implements ScheduleHandle.
+func (h *testFakeScheduleHandle) Pause(ctx context.Context, options SchedulePauseOptions) error {
+	note := "Paused via Go SDK"
+	if options.Note != "" {
+		note = options.Note
+	}
+	return h.withSchedule(func(s *testFakeSchedule) {
+		s.state.Paused = true
+		s.state.Note = note
+		s.info.LastUpdateAt = h.client.env.Now().UTC()
+	})
+}
+
+// Unpause implements ScheduleHandle.
+func (h *testFakeScheduleHandle) Unpause(ctx context.Context, options ScheduleUnpauseOptions) error {
+	note := "Unpaused via Go SDK"
+	if options.Note != "" {
+		note = options.Note
+	}
+	return h.withSchedule(func(s *testFakeSchedule) {
+		s.state.Paused = false
+		s.state.Note = note
+		s.info.LastUpdateAt = h.client.env.Now().UTC()
+	})
+}
+
+// HasNext implements ScheduleListIterator.
+func (iter *testFakeScheduleListIterator) HasNext() bool {
+	return len(iter.entries) > 0
+}
+
+// Next implements ScheduleListIterator.
+func (iter *testFakeScheduleListIterator) Next() (*ScheduleListEntry, error) {
+	if len(iter.entries) == 0 {
+		return nil, errors.New("no more schedules")
+	}
+	entry := iter.entries[0]
+	iter.entries = iter.entries[1:]
+	return entry, nil
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/sdk/converter"
+)
+
+var fakeScheduleStartTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fakeScheduleWorkflow(ctx Context, duration time.Duration) (string, error) {
+	if err := Sleep(ctx, duration); err != nil {
+		return "", err
+	}
+	info := GetWorkflowInfo(ctx)
+	searchAttributes := GetTypedSearchAttributes(ctx)
+	scheduledBy, _ := searchAttributes.GetKeyword(NewSearchAttributeKeyKeyword(scheduledByIDSearchAttribute))
+	scheduledTime, _ := searchAttributes.GetTime(NewSearchAttributeKeyTime(scheduledStartTimeSearchAttribute))
+	var team string
+	if payload := info.Memo.GetFields()["team"]; payload != nil {
+		if err := converter.GetDefaultDataConverter().FromPayload(payload, &team); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%s %s %s", scheduledBy, scheduledTime.UTC().Format(time.RFC3339), team), nil
+}
+
+func newFakeScheduleClientForTest(t *testing.T) Client {
+	env := (&WorkflowTestSuite{}).NewTestWorkflowEnvironment()
+	env.SetStartTime(fakeScheduleStartTime)
+	env.RegisterWorkflow(fakeScheduleWorkflow)
+	c := NewFakeClient(env)
+	t.Cleanup(c.Close)
+	return c
+}
+
+func fakeScheduleOptions(id string, duration time.Duration, overlap enumspb.ScheduleOverlapPolicy) ScheduleOptions {
+	return ScheduleOptions{
+		ID:   id,
+		Spec: ScheduleSpec{Intervals: []ScheduleIntervalSpec{{Every: time.Hour}}},
+		Action: &ScheduleWorkflowAction{
+			ID:        id + "-workflow",
+			Workflow:  fakeScheduleWorkflow,
+			Args:      []interface{}{duration},
+			TaskQueue: "tq",
+			Memo:      map[string]interface{}{"team": "data"},
+		},
+		Overlap: overlap,
+	}
+}
+
+func TestFakeClient_Schedule(t *testing.T) {
+	t.Parallel()
+	c := newFakeScheduleClientForTest(t)
+	ctx := context.Background()
+
+	handle, err := c.ScheduleClient().Create(ctx, fakeScheduleOptions("hourly", time.Minute, enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED))
+	require.NoError(t, err)
+	_, err = c.ScheduleClient().Create(ctx, fakeScheduleOptions("hourly", time.Minute, enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED))
+	require.ErrorIs(t, err, ErrScheduleAlreadyRunning)
+
+	require.NoError(t, SleepFakeClient(ctx, c, 2*time.Hour+30*time.Minute))
+	var result string
+	require.NoError(t, c.GetWorkflow(ctx, "hourly-workflow-2024-01-01T02:00:00Z", "").Get(ctx, &result))
+	require.Equal(t, "hourly 2024-01-01T02:00:00Z data", result)
+
+	description, err := handle.Describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, description.Info.NumActions)
+	require.Equal(t, fakeScheduleStartTime.Add(time.Hour), description.Info.RecentActions[0].ScheduleTime)
+	require.Equal(t, "hourly-workflow-2024-01-01T01:00:00Z", description.Info.RecentActions[0].StartWorkflowResult.WorkflowID)
+	require.Len(t, description.Info.NextActionTimes, testFakeScheduleActionCount)
+	require.Equal(t, fakeScheduleStartTime.Add(3*time.Hour), description.Info.NextActionTimes[0])
+	require.Empty(t, description.Info.RunningWorkflows)
+
+	// Paused schedules only take triggered actions.
+	require.NoError(t, handle.Pause(ctx, SchedulePauseOptions{}))
+	require.NoError(t, SleepFakeClient(ctx, c, 2*time.Hour))
+	require.NoError(t, handle.Trigger(ctx, ScheduleTriggerOptions{}))
+	description, err = handle.Describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, description.Info.NumActions)
+	require.Equal(t, "Paused via Go SDK", description.Schedule.State.Note)
+	require.Empty(t, description.Info.NextActionTimes)
+	require.NoError(t, handle.Unpause(ctx, ScheduleUnpauseOptions{}))
+
+	require.NoError(t, handle.Backfill(ctx, ScheduleBackfillOptions{Backfill: []ScheduleBackfill{{
+		Start:   fakeScheduleStartTime.Add(-2 * time.Hour),
+		End:     fakeScheduleStartTime,
+		Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_ALLOW_ALL,
+	}}}))
+	require.NoError(t, c.GetWorkflow(ctx, "hourly-workflow-2023-12-31T22:00:00Z", "").Get(ctx, &result))
+	require.Equal(t, "hourly 2023-12-31T22:00:00Z data", result)
+	description, err = handle.Describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 6, description.Info.NumActions)
+
+	require.NoError(t, handle.Update(ctx, ScheduleUpdateOptions{
+		DoUpdate: func(input ScheduleUpdateInput) (*ScheduleUpdate, error) {
+			input.Description.Schedule.Spec = &ScheduleSpec{CronExpressions: []string{"30 * * * *"}}
+			input.Description.Schedule.State.LimitedActions = true
+			input.Description.Schedule.State.RemainingActions = 1
+			return &ScheduleUpdate{Schedule: &input.Description.Schedule}, nil
+		},
+	}))
+	require.NoError(t, SleepFakeClient(ctx, c, 3*time.Hour))
+	description, err = handle.Describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 7, description.Info.NumActions)
+	require.Equal(t, 30, description.Info.RecentActions[len(description.Info.RecentActions)-1].ScheduleTime.Minute())
+	require.Equal(t, 0, description.Schedule.State.RemainingActions)
+
+	iter, err := c.ScheduleClient().List(ctx, ScheduleListOptions{})
+	require.NoError(t, err)
+	require.True(t, iter.HasNext())
+	entry, err := iter.Next()
+	require.NoError(t, err)
+	require.Equal(t, "hourly", entry.ID)
+	require.Equal(t, "fakeScheduleWorkflow", entry.WorkflowType.Name)
+	require.False(t, iter.HasNext())
+
+	require.NoError(t, handle.Delete(ctx))
+	_, err = handle.Describe(ctx)
+	var notFoundErr *serviceerror.NotFound
+	require.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestFakeClient_ScheduleOverlap(t *testing.T) {
+	t.Parallel()
+	c := newFakeScheduleClientForTest(t)
+	ctx := context.Background()
+
+	// Each workflow runs for 90 minutes, while actions are taken every hour.
+	skip, err := c.ScheduleClient().Create(ctx, fakeScheduleOptions("skip", 90*time.Minute, enumspb.SCHEDULE_OVERLAP_POLICY_SKIP))
+	require.NoError(t, err)
+	bufferOne, err := c.ScheduleClient().Create(ctx, fakeScheduleOptions("buffer-one", 90*time.Minute, enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE))
+	require.NoError(t, err)
+	terminate, err := c.ScheduleClient().Create(ctx, fakeScheduleOptions("terminate", 90*time.Minute, enumspb.SCHEDULE_OVERLAP_POLICY_TERMINATE_OTHER))
+	require.NoError(t, err)
+	require.NoError(t, SleepFakeClient(ctx, c, 3*time.Hour+10*time.Minute))
+
+	description, err := skip.Describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, description.Info.NumActions)
+	require.Equal(t, 1, description.Info.NumActionsSkippedOverlap)
+	require.Len(t, description.Info.RunningWorkflows, 1)
+
+	description, err = bufferOne.Describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, description.Info.NumActions)
+	require.Equal(t, fakeScheduleStartTime.Add(2*time.Hour), description.Info.RecentActions[1].ScheduleTime)
+	require.Equal(t, fakeScheduleStartTime.Add(150*time.Minute), description.Info.RecentActions[1].ActualTime)
+
+	description, err = terminate.Describe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, description.Info.NumActions)
+	var terminatedErr *TerminatedError
+	require.ErrorAs(t, c.GetWorkflow(ctx, "terminate-workflow-2024-01-01T01:00:00Z", "").Get(ctx, nil), &terminatedErr)
+}
@@ -590,6 +590,90 @@ func (_m *Client) ListArchivedWorkflow(ctx context.Context, request *workflowser
 	return r0, r1
 }
 
+// ListWorkflowExecutions provides a mock function with given fields: ctx, options
+func (_m *Client) ListWorkflowExecutions(ctx context.Context, options client.ListWorkflowExecutionsOptions) (client.ListWorkflowExecutionsResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkflowExecutions")
+	}
+
+	var r0 client.ListWorkflowExecutionsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListWorkflowExecutionsOptions) (client.ListWorkflowExecutionsResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListWorkflowExecutionsOptions) client.ListWorkflowExecutionsResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Get(0).(client.ListWorkflowExecutionsResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ListWorkflowExecutionsOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListArchivedWorkflowExecutions provides a mock function with given fields: ctx, options
+func (_m *Client) ListArchivedWorkflowExecutions(ctx context.Context, options client.ListWorkflowExecutionsOptions) (client.ListWorkflowExecutionsResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListArchivedWorkflowExecutions")
+	}
+
+	var r0 client.ListWorkflowExecutionsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListWorkflowExecutionsOptions) (client.ListWorkflowExecutionsResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListWorkflowExecutionsOptions) client.ListWorkflowExecutionsResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Get(0).(client.ListWorkflowExecutionsResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ListWorkflowExecutionsOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClosedWorkflowExecutions provides a mock function with given fields: ctx, options
+func (_m *Client) ListClosedWorkflowExecutions(ctx context.Context, options client.ListClosedWorkflowExecutionsOptions) (client.ListWorkflowExecutionsResult, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListClosedWorkflowExecutions")
+	}
+
+	var r0 client.ListWorkflowExecutionsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListClosedWorkflowExecutionsOptions) (client.ListWorkflowExecutionsResult, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.ListClosedWorkflowExecutionsOptions) client.ListWorkflowExecutionsResult); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Get(0).(client.ListWorkflowExecutionsResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.ListClosedWorkflowExecutionsOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListClosedWorkflow provides a mock function with given fields: ctx, request
 func (_m *Client) ListClosedWorkflow(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -98,6 +98,33 @@ func ExampleNewWorkflowRunOperationWithOptions() {
 	w.RegisterNexusService(service)
 }
 
+func ExampleNewSignalWithStartOperation() {
+	op := temporalnexus.NewSignalWithStartOperation(
+		"my-signal-with-start-operation",
+		MyHandlerWorkflow,
+		func(ctx context.Context, input MyInput, opts nexus.StartOperationOptions) (temporalnexus.SignalWithStartOperationOptions, error) {
+			return temporalnexus.SignalWithStartOperationOptions{
+				StartWorkflowOptions: client.StartWorkflowOptions{
+					ID: input.ID,
+				},
+				// The input is sent as the signal argument unless SignalArg is set.
+				SignalName:   "my-signal",
+				WorkflowArgs: []any{input},
+			}, nil
+		})
+
+	service := nexus.NewService("my-service")
+	_ = service.Register(op)
+
+	c, _ := client.Dial(client.Options{
+		HostPort:  "localhost:7233",
+		Namespace: "my-namespace",
+	})
+	w := worker.New(c, "my-task-queue", worker.Options{})
+	w.RegisterWorkflow(MyHandlerWorkflow)
+	w.RegisterNexusService(service)
+}
+
 func ExampleNewSyncOperation() {
 	opRead := nexus.NewSyncOperation("my-read-only-operation", func(ctx context.Context, input MyInput, opts nexus.StartOperationOptions) (MyQueryOutput, error) {
 		var ret MyQueryOutput
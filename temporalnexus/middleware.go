@@ -0,0 +1,67 @@
+package temporalnexus
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nexus-rpc/sdk-go/nexus"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/internal"
+)
+
+// MiddlewareRequest describes a Nexus request to a worker before it is passed to the operation handler.
+//
+// NOTE: Experimental
+type MiddlewareRequest = internal.NexusMiddlewareRequest
+
+// Middleware is called for each Nexus request handled by a worker, before the operation handler. It returns the
+// context to pass on or an error, typically a [*nexus.HandlerError], to reject the request. Set it in
+// [go.temporal.io/sdk/worker.Options.NexusMiddleware].
+//
+// NOTE: Experimental
+type Middleware = internal.NexusMiddleware
+
+// CallerNamespace returns the namespace of the workflow that started the operation, taken from the links the caller
+// sent with a start request, or an empty string if there is no such link. Links are set by the caller and not
+// verified by the server, so the namespace is only as trustworthy as the callers that can reach the endpoint.
+//
+// NOTE: Experimental
+func CallerNamespace(request *MiddlewareRequest) string {
+	workflowEventType := string((&common.Link_WorkflowEvent{}).ProtoReflect().Descriptor().FullName())
+	for _, link := range request.Links {
+		if link.Type != workflowEventType {
+			continue
+		}
+		if event, err := ConvertNexusLinkToLinkWorkflowEvent(link); err == nil && event.GetNamespace() != "" {
+			return event.GetNamespace()
+		}
+	}
+	return ""
+}
+
+// NewCallerNamespaceAllowlistMiddleware creates a middleware that rejects start requests whose [CallerNamespace] isn't
+// one of the given namespaces with a bad request handler error. This includes requests that don't come from a
+// workflow, such as those sent directly over HTTP. Cancel requests carry no links and are passed on.
+//
+// This is not an access control: any caller that can reach the endpoint can forge the links the namespace is taken
+// from. Use it to keep well-behaved callers from using operations they aren't meant to, and restrict who can call the
+// endpoint with the endpoint's allowed caller namespaces on the server.
+//
+// NOTE: Experimental
+func NewCallerNamespaceAllowlistMiddleware(namespaces ...string) Middleware {
+	return func(ctx context.Context, request *MiddlewareRequest) (context.Context, error) {
+		if request.StartOptions == nil {
+			return ctx, nil
+		}
+		if callerNamespace := CallerNamespace(request); !slices.Contains(namespaces, callerNamespace) {
+			return nil, nexus.NewHandlerErrorf(
+				nexus.HandlerErrorTypeBadRequest,
+				"caller namespace %q is not allowed to call %s/%s",
+				callerNamespace,
+				request.Service,
+				request.Operation,
+			)
+		}
+		return ctx, nil
+	}
+}
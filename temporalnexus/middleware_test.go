@@ -0,0 +1,59 @@
+package temporalnexus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nexus-rpc/sdk-go/nexus"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporalnexus"
+)
+
+func TestCallerNamespaceAllowlistMiddleware(t *testing.T) {
+	callerLink := func(namespace string) nexus.Link {
+		return temporalnexus.ConvertLinkWorkflowEventToNexusLink(&common.Link_WorkflowEvent{
+			Namespace:  namespace,
+			WorkflowId: "caller-wf-id",
+			RunId:      "caller-run-id",
+			Reference: &common.Link_WorkflowEvent_EventRef{
+				EventRef: &common.Link_WorkflowEvent_EventReference{
+					EventType: enums.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED,
+				},
+			},
+		})
+	}
+	startRequest := func(links ...nexus.Link) *temporalnexus.MiddlewareRequest {
+		return &temporalnexus.MiddlewareRequest{
+			Service:      "service",
+			Operation:    "operation",
+			Links:        links,
+			StartOptions: &nexus.StartOperationOptions{Links: links},
+		}
+	}
+	middleware := temporalnexus.NewCallerNamespaceAllowlistMiddleware("allowed")
+
+	require.Equal(t, "allowed", temporalnexus.CallerNamespace(startRequest(callerLink("allowed"))))
+	ctx, err := middleware(context.Background(), startRequest(callerLink("allowed")))
+	require.NoError(t, err)
+	require.NotNil(t, ctx)
+
+	for _, request := range []*temporalnexus.MiddlewareRequest{
+		startRequest(callerLink("other")),
+		startRequest(),
+	} {
+		_, err = middleware(context.Background(), request)
+		var handlerErr *nexus.HandlerError
+		require.ErrorAs(t, err, &handlerErr)
+		require.Equal(t, nexus.HandlerErrorTypeBadRequest, handlerErr.Type)
+		require.Contains(t, handlerErr.Message, "is not allowed to call service/operation")
+	}
+
+	_, err = middleware(context.Background(), &temporalnexus.MiddlewareRequest{
+		Service:       "service",
+		Operation:     "operation",
+		CancelOptions: &nexus.CancelOperationOptions{},
+	})
+	require.NoError(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/temporalnexus"
+	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -74,3 +75,35 @@ func TestNewWorkflowRunOperationWithOptions(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestNewSignalWithStartOperationNames(t *testing.T) {
+	require.PanicsWithError(t, "temporalnexus NewSignalWithStartOperation __temporal_ is an invalid name", func() {
+		temporalnexus.NewSignalWithStartOperation[string]("__temporal_test", "wf", nil)
+	})
+	require.Equal(t, "signal", temporalnexus.NewSignalWithStartOperation[string]("signal", "wf", nil).Name())
+}
+
+func TestSignalWithStartOperationUnresolvedWorkflow(t *testing.T) {
+	op := temporalnexus.NewSignalWithStartOperation("signal", 42,
+		func(context.Context, string, nexus.StartOperationOptions) (temporalnexus.SignalWithStartOperationOptions, error) {
+			return temporalnexus.SignalWithStartOperationOptions{
+				StartWorkflowOptions: client.StartWorkflowOptions{ID: "workflow-id"},
+				SignalName:           "signal",
+			}, nil
+		})
+	service := nexus.NewService("test")
+	require.NoError(t, service.Register(op))
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterNexusService(service)
+	env.ExecuteWorkflow(func(ctx workflow.Context) error {
+		c := workflow.NewNexusClient("endpoint", "test")
+		return c.ExecuteOperation(ctx, op, "input", workflow.NexusOperationOptions{}).Get(ctx, nil)
+	})
+	require.True(t, env.IsWorkflowCompleted())
+	var handlerErr *nexus.HandlerError
+	require.ErrorAs(t, env.GetWorkflowError(), &handlerErr)
+	require.Equal(t, nexus.HandlerErrorTypeInternal, handlerErr.Type)
+	require.ErrorContains(t, handlerErr, "could not resolve the workflow to signal with start")
+}
@@ -0,0 +1,136 @@
+package temporalnexus
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/nexus-rpc/sdk-go/nexus"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/internal"
+)
+
+// SignalWithStartOperationOptions are the options returned by the GetOptions function of a
+// [NewSignalWithStartOperation] operation.
+//
+// NOTE: Experimental
+type SignalWithStartOperationOptions struct {
+	// StartWorkflowOptions are the options used to start the workflow if it isn't running. ID is required and
+	// TaskQueue defaults to the task queue of the Nexus worker.
+	StartWorkflowOptions client.StartWorkflowOptions
+	// SignalName is the name of the signal to send. Required.
+	SignalName string
+	// SignalArg is the argument of the signal. If nil, the operation input is sent.
+	SignalArg any
+	// WorkflowArgs are the arguments the workflow is started with.
+	WorkflowArgs []any
+}
+
+type signalWithStartOperation[I any] struct {
+	nexus.UnimplementedOperation[I, nexus.NoValue]
+
+	name       string
+	workflow   any
+	getOptions func(context.Context, I, nexus.StartOperationOptions) (SignalWithStartOperationOptions, error)
+}
+
+// NewSignalWithStartOperation maps an operation to signaling a workflow, starting it first if it isn't running. The
+// workflow is given by function reference or by name. The operation's request ID and the caller's links are attached
+// to the request, and a link to the WorkflowExecutionSignaled event is returned to the caller. The operation
+// completes synchronously once the signal is sent.
+//
+// NOTE: Experimental
+func NewSignalWithStartOperation[I any](
+	name string,
+	workflow any,
+	getOptions func(context.Context, I, nexus.StartOperationOptions) (SignalWithStartOperationOptions, error),
+) nexus.Operation[I, nexus.NoValue] {
+	if strings.HasPrefix(name, "__temporal_") {
+		panic(errors.New("temporalnexus NewSignalWithStartOperation __temporal_ is an invalid name"))
+	}
+	return &signalWithStartOperation[I]{
+		name:       name,
+		workflow:   workflow,
+		getOptions: getOptions,
+	}
+}
+
+func (o *signalWithStartOperation[I]) Name() string {
+	return o.name
+}
+
+// Start signals the workflow, starting it if needed.
+func (o *signalWithStartOperation[I]) Start(
+	ctx context.Context,
+	input I,
+	options nexus.StartOperationOptions,
+) (nexus.HandlerStartOperationResult[nexus.NoValue], error) {
+	nctx, ok := internal.NexusOperationContextFromGoContext(ctx)
+	if !ok {
+		return nil, nexus.NewHandlerErrorf(nexus.HandlerErrorTypeInternal, "internal error")
+	}
+
+	workflowType, err := nctx.ResolveWorkflowName(o.workflow)
+	if err != nil {
+		return nil, &nexus.HandlerError{
+			Type:    nexus.HandlerErrorTypeInternal,
+			Message: "could not resolve the workflow to signal with start",
+			Cause:   err,
+		}
+	}
+
+	opts, err := o.getOptions(ctx, input, options)
+	if err != nil {
+		return nil, err
+	}
+	startWorkflowOptions := opts.StartWorkflowOptions
+	if startWorkflowOptions.TaskQueue == "" {
+		startWorkflowOptions.TaskQueue = nctx.TaskQueue
+	}
+	if startWorkflowOptions.ID == "" {
+		return nil, internal.ErrMissingWorkflowID
+	}
+	var signalArg any = input
+	if opts.SignalArg != nil {
+		signalArg = opts.SignalArg
+	}
+
+	if options.RequestID != "" {
+		internal.SetRequestIDOnStartWorkflowOptions(&startWorkflowOptions, options.RequestID)
+	}
+	links, err := convertNexusLinks(options.Links, GetLogger(ctx))
+	if err != nil {
+		return nil, &nexus.HandlerError{
+			Type:    nexus.HandlerErrorTypeBadRequest,
+			Message: "could not convert links for signal with start",
+			Cause:   err,
+		}
+	}
+	internal.SetLinksOnStartWorkflowOptions(&startWorkflowOptions, links)
+
+	run, err := GetClient(ctx).SignalWithStartWorkflow(
+		ctx,
+		startWorkflowOptions.ID,
+		opts.SignalName,
+		signalArg,
+		startWorkflowOptions,
+		workflowType,
+		opts.WorkflowArgs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	nexus.AddHandlerLinks(ctx, ConvertLinkWorkflowEventToNexusLink(&common.Link_WorkflowEvent{
+		Namespace:  nctx.Namespace,
+		WorkflowId: run.GetID(),
+		RunId:      run.GetRunID(),
+		Reference: &common.Link_WorkflowEvent_EventRef{
+			EventRef: &common.Link_WorkflowEvent_EventReference{
+				EventType: enums.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+			},
+		},
+	}))
+	return &nexus.HandlerStartOperationResultSync[nexus.NoValue]{}, nil
+}
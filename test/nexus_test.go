@@ -473,6 +473,74 @@ func TestNexusWorkflowRunOperation(t *testing.T) {
 	require.ErrorContains(t, run.Get(ctx, nil), "canceled")
 }
 
+func nexusSignalHandlerWorkflow(ctx workflow.Context, greeting string) (string, error) {
+	var name string
+	workflow.GetSignalChannel(ctx, "greet").Receive(ctx, &name)
+	return greeting + " " + name, nil
+}
+
+func TestNexusSignalWithStartOperation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultNexusTestTimeout)
+	defer cancel()
+	tc := newTestContext(t, ctx)
+
+	workflowID := "nexus-signal-with-start-workflow-" + uuid.NewString()
+	signalOp := temporalnexus.NewSignalWithStartOperation(
+		"signal-with-start-op",
+		nexusSignalHandlerWorkflow,
+		func(ctx context.Context, s string, o nexus.StartOperationOptions) (temporalnexus.SignalWithStartOperationOptions, error) {
+			return temporalnexus.SignalWithStartOperationOptions{
+				StartWorkflowOptions: client.StartWorkflowOptions{ID: workflowID},
+				SignalName:           "greet",
+				WorkflowArgs:         []any{"hello"},
+			}, nil
+		},
+	)
+	w := worker.New(tc.client, tc.taskQueue, worker.Options{})
+	service := nexus.NewService("test")
+	require.NoError(t, service.Register(signalOp))
+	w.RegisterNexusService(service)
+	w.RegisterWorkflow(nexusSignalHandlerWorkflow)
+	require.NoError(t, w.Start())
+	t.Cleanup(w.Stop)
+
+	nc := tc.newNexusClient(t, service.Name)
+	callerLink := &common.Link_WorkflowEvent{
+		Namespace:  tc.testConfig.Namespace,
+		WorkflowId: "caller-wf-id",
+		RunId:      "caller-run-id",
+		Reference: &common.Link_WorkflowEvent_EventRef{
+			EventRef: &common.Link_WorkflowEvent_EventReference{
+				EventType: enumspb.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED,
+			},
+		},
+	}
+	result, err := nexusclient.StartOperation(ctx, nc, signalOp, "world", nexus.StartOperationOptions{
+		Links: []nexus.Link{temporalnexus.ConvertLinkWorkflowEventToNexusLink(callerLink)},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Links, 1)
+	link, err := temporalnexus.ConvertNexusLinkToLinkWorkflowEvent(result.Links[0])
+	require.NoError(t, err)
+	require.Equal(t, workflowID, link.WorkflowId)
+	require.Equal(t, enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED, link.GetEventRef().GetEventType())
+
+	var greeting string
+	require.NoError(t, tc.client.GetWorkflow(ctx, workflowID, link.RunId).Get(ctx, &greeting))
+	require.Equal(t, "hello world", greeting)
+
+	iter := tc.client.GetWorkflowHistory(ctx, workflowID, link.RunId, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		require.NoError(t, err)
+		if event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED {
+			require.Len(t, event.GetLinks(), 1)
+			require.True(t, proto.Equal(callerLink, event.GetLinks()[0].GetWorkflowEvent()))
+			break
+		}
+	}
+}
+
 func TestOperationSummary(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultNexusTestTimeout)
 	defer cancel()
@@ -2610,6 +2678,84 @@ func TestWorkflowTestSuite_WorkflowRunOperation_MultipleCallers(t *testing.T) {
 	}
 }
 
+func TestWorkflowTestSuite_WorkflowRunOperation_CancellationTypes(t *testing.T) {
+	handlerWF := func(ctx workflow.Context, _ string) (string, error) {
+		_, err := workflow.AwaitWithTimeout(ctx, 24*time.Hour, func() bool { return false })
+		// Take a while to complete after being canceled so callers waiting for completion are unblocked later.
+		disconCtx, _ := workflow.NewDisconnectedContext(ctx)
+		_ = workflow.Sleep(disconCtx, time.Hour)
+		return "", err
+	}
+	op := temporalnexus.NewWorkflowRunOperation(
+		"op",
+		handlerWF,
+		func(ctx context.Context, _ string, opts nexus.StartOperationOptions) (client.StartWorkflowOptions, error) {
+			// The caller links to itself like it does outside of the test environment.
+			if len(opts.Links) != 1 {
+				return client.StartWorkflowOptions{}, fmt.Errorf("expected a link to the caller, got: %v", opts.Links)
+			}
+			link, err := temporalnexus.ConvertNexusLinkToLinkWorkflowEvent(opts.Links[0])
+			if err != nil {
+				return client.StartWorkflowOptions{}, err
+			}
+			if link.GetEventRef().GetEventType() != enumspb.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED {
+				return client.StartWorkflowOptions{}, fmt.Errorf("unexpected caller link: %v", link)
+			}
+			return client.StartWorkflowOptions{ID: "handler-of-" + link.WorkflowId}, nil
+		})
+	callerWF := func(ctx workflow.Context, cancellationType workflow.NexusOperationCancellationType) (time.Duration, error) {
+		start := workflow.Now(ctx)
+		opCtx, cancel := workflow.WithCancel(ctx)
+		client := workflow.NewNexusClient("endpoint", "test")
+		fut := client.ExecuteOperation(opCtx, op, "", workflow.NexusOperationOptions{CancellationType: cancellationType})
+		if err := fut.GetNexusOperationExecution().Get(ctx, nil); err != nil {
+			return 0, err
+		}
+		if err := workflow.Sleep(ctx, time.Minute); err != nil {
+			return 0, err
+		}
+		cancel()
+		err := fut.Get(ctx, nil)
+		var canceledErr *temporal.CanceledError
+		if !errors.As(err, &canceledErr) {
+			return 0, fmt.Errorf("expected canceled error, got: %w", err)
+		}
+		return workflow.Now(ctx).Sub(start), nil
+	}
+
+	service := nexus.NewService("test")
+	service.MustRegister(op)
+
+	for _, tc := range []struct {
+		cancellationType workflow.NexusOperationCancellationType
+		canceled         bool
+		unblockedAfter   time.Duration
+	}{
+		{workflow.NexusOperationCancellationTypeAbandon, false, time.Minute},
+		{workflow.NexusOperationCancellationTypeTryCancel, true, time.Minute},
+		{workflow.NexusOperationCancellationTypeWaitRequested, true, time.Minute},
+		{workflow.NexusOperationCancellationTypeWaitCompleted, true, time.Minute + time.Hour},
+	} {
+		t.Run(fmt.Sprint(tc.cancellationType), func(t *testing.T) {
+			suite := testsuite.WorkflowTestSuite{}
+			env := suite.NewTestWorkflowEnvironment()
+			env.RegisterWorkflow(handlerWF)
+			env.RegisterNexusService(service)
+			var canceled bool
+			env.SetOnNexusOperationCanceledListener(func(service, operation string) {
+				canceled = true
+			})
+			env.ExecuteWorkflow(callerWF, tc.cancellationType)
+			require.True(t, env.IsWorkflowCompleted())
+			require.NoError(t, env.GetWorkflowError())
+			var unblockedAfter time.Duration
+			require.NoError(t, env.GetWorkflowResult(&unblockedAfter))
+			require.Equal(t, tc.unblockedAfter, unblockedAfter)
+			require.Equal(t, tc.canceled, canceled)
+		})
+	}
+}
+
 func TestWorkflowTestSuite_NexusSyncOperation_ScheduleToCloseTimeout(t *testing.T) {
 	sleepDuration := 500 * time.Millisecond
 	op := nexus.NewSyncOperation(
@@ -2953,6 +3099,86 @@ func TestWorkflowTestSuite_MockNexusOperation(t *testing.T) {
 	})
 }
 
+type nexusTenantKey struct{}
+
+func TestWorkflowTestSuite_NexusMiddleware(t *testing.T) {
+	op := nexus.NewSyncOperation("op", func(ctx context.Context, _ string, opts nexus.StartOperationOptions) (string, error) {
+		return ctx.Value(nexusTenantKey{}).(string), nil
+	})
+	forbiddenOp := nexus.NewSyncOperation("forbidden-op", func(ctx context.Context, _ string, opts nexus.StartOperationOptions) (string, error) {
+		return "", errors.New("operation should not be called")
+	})
+	wf := func(ctx workflow.Context, operation string) (string, error) {
+		client := workflow.NewNexusClient("endpoint", "test")
+		var result string
+		err := client.ExecuteOperation(ctx, operation, "input", workflow.NexusOperationOptions{}).Get(ctx, &result)
+		return result, err
+	}
+	service := nexus.NewService("test")
+	service.MustRegister(op, forbiddenOp)
+
+	var requests []string
+	middleware := []temporalnexus.Middleware{
+		func(ctx context.Context, request *temporalnexus.MiddlewareRequest) (context.Context, error) {
+			requests = append(requests, request.Service+"/"+request.Operation)
+			if request.Operation == forbiddenOp.Name() {
+				return nil, nexus.NewHandlerErrorf(nexus.HandlerErrorTypeUnauthorized, "operation is forbidden")
+			}
+			return context.WithValue(ctx, nexusTenantKey{}, "tenant-of-"+request.Endpoint), nil
+		},
+		func(ctx context.Context, request *temporalnexus.MiddlewareRequest) (context.Context, error) {
+			require.NotNil(t, request.StartOptions)
+			require.NotEmpty(t, request.StartOptions.RequestID)
+			require.Equal(t, "tenant-of-endpoint", ctx.Value(nexusTenantKey{}))
+			return ctx, nil
+		},
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		suite := testsuite.WorkflowTestSuite{}
+		env := suite.NewTestWorkflowEnvironment()
+		env.SetWorkerOptions(worker.Options{NexusMiddleware: middleware})
+		env.RegisterNexusService(service)
+		env.ExecuteWorkflow(wf, op.Name())
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		var result string
+		require.NoError(t, env.GetWorkflowResult(&result))
+		require.Equal(t, "tenant-of-endpoint", result)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		suite := testsuite.WorkflowTestSuite{}
+		env := suite.NewTestWorkflowEnvironment()
+		env.SetWorkerOptions(worker.Options{NexusMiddleware: middleware})
+		env.RegisterNexusService(service)
+		env.ExecuteWorkflow(wf, forbiddenOp.Name())
+		require.True(t, env.IsWorkflowCompleted())
+		var handlerErr *nexus.HandlerError
+		require.ErrorAs(t, env.GetWorkflowError(), &handlerErr)
+		require.Equal(t, nexus.HandlerErrorTypeUnauthorized, handlerErr.Type)
+		require.Equal(t, "operation is forbidden", handlerErr.Message)
+	})
+
+	t.Run("panicked", func(t *testing.T) {
+		suite := testsuite.WorkflowTestSuite{}
+		env := suite.NewTestWorkflowEnvironment()
+		env.SetWorkerOptions(worker.Options{NexusMiddleware: []temporalnexus.Middleware{
+			func(ctx context.Context, request *temporalnexus.MiddlewareRequest) (context.Context, error) {
+				panic("middleware panic")
+			},
+		}})
+		env.RegisterNexusService(service)
+		env.ExecuteWorkflow(wf, op.Name())
+		require.True(t, env.IsWorkflowCompleted())
+		var handlerErr *nexus.HandlerError
+		require.ErrorAs(t, env.GetWorkflowError(), &handlerErr)
+		require.Equal(t, nexus.HandlerErrorTypeInternal, handlerErr.Type)
+	})
+
+	require.Equal(t, []string{"test/op", "test/forbidden-op"}, requests)
+}
+
 func TestWorkflowTestSuite_NexusListeners(t *testing.T) {
 	startedListenerCalled := false
 	completedListenerCalled := false
@@ -3,6 +3,10 @@
 package testsuite
 
 import (
+	"context"
+	"time"
+
+	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/internal"
 )
 
@@ -25,3 +29,24 @@ type (
 
 // ErrMockStartChildWorkflowFailed is special error used to indicate the mocked child workflow should fail to start.
 var ErrMockStartChildWorkflowFailed = internal.ErrMockStartChildWorkflowFailed
+
+// NewFakeClient creates a client that runs workflows in the given test environment instead of on a server. Workflows
+// and activities registered with the environment really run, with time skipped while a call waits for a result.
+//
+// The supported methods are ExecuteWorkflow, GetWorkflow, SignalWorkflow, SignalWithStartWorkflow, CancelWorkflow,
+// TerminateWorkflow, QueryWorkflow, UpdateWorkflow, GetWorkflowUpdateHandle, ScheduleClient, CheckHealth and Close.
+// The other methods need a server, such as DescribeWorkflow, those listing workflows or activities and
+// GetWorkflowHistory, and return a serviceerror.Unimplemented error.
+//
+// NOTE: Experimental
+func NewFakeClient(env *TestWorkflowEnvironment) client.Client {
+	return internal.NewFakeClient(env)
+}
+
+// SleepFakeClient skips the given duration of the simulated time of a client created with [NewFakeClient], during
+// which timers fire and schedules take their actions.
+//
+// NOTE: Experimental
+func SleepFakeClient(ctx context.Context, c client.Client, d time.Duration) error {
+	return internal.SleepFakeClient(ctx, c, d)
+}
@@ -3,6 +3,8 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
 	"github.com/nexus-rpc/sdk-go/nexus"
 	historypb "go.temporal.io/api/history/v1"
@@ -211,6 +213,41 @@ type (
 	// Options is used to configure a worker instance.
 	Options = internal.WorkerOptions
 
+	// HealthHandlerOptions configures the handler returned by NewHealthHandler.
+	//
+	// NOTE: Experimental
+	HealthHandlerOptions = internal.WorkerHealthHandlerOptions
+
+	// DrainProgress describes how far along a draining worker is. See Drain.
+	//
+	// NOTE: Experimental
+	DrainProgress = internal.DrainProgress
+
+	// ActivityRateLimitOptions configures rate limiting of activity executions
+	// by a key extracted from each activity task. See
+	// Options.ActivityRateLimit.
+	//
+	// NOTE: Experimental
+	ActivityRateLimitOptions = internal.ActivityRateLimitOptions
+
+	// ActivityRateLimiter is a rate limiter backend keyed by a value extracted
+	// from activity tasks.
+	//
+	// NOTE: Experimental
+	ActivityRateLimiter = internal.ActivityRateLimiter
+
+	// ActivityRateLimiterOptions configures the limiter returned by
+	// NewActivityRateLimiter.
+	//
+	// NOTE: Experimental
+	ActivityRateLimiterOptions = internal.ActivityRateLimiterOptions
+
+	// LogSamplingOptions configures sampling and deduplication of log lines
+	// written through a worker's logger. See Options.LogSampling.
+	//
+	// NOTE: Experimental
+	LogSamplingOptions = internal.LogSamplingOptions
+
 	// PollerBehavior is used to configure the behavior of the poller.
 	PollerBehavior = internal.PollerBehavior
 
@@ -263,6 +300,54 @@ func New(
 	return internal.NewWorker(client, taskQueue, options)
 }
 
+// NewHealthHandler returns an http.Handler that reports the health of the given
+// workers, which must have been created with New. Requests whose path ends in
+// "/livez" report liveness and requests whose path ends in "/readyz" report
+// readiness, so the handler can be mounted under any prefix. Both respond with
+// 200 when healthy and 503 otherwise, along with a JSON body describing the
+// state of each worker and its pollers.
+//
+// A worker is live unless it has stopped, one of its workflow goroutines is
+// deadlocked, or one of its pollers is stuck, having no poll return for longer
+// than HealthHandlerOptions.StuckPollerThreshold while the worker has no task to
+// execute. A worker is ready when it is live, started, not draining, its client
+// is connected to the server, and each of its pollers has recently received a
+// response to a poll without error.
+//
+// NOTE: Experimental
+func NewHealthHandler(options HealthHandlerOptions, workers ...Worker) http.Handler {
+	aggregatedWorkers := make([]*internal.AggregatedWorker, len(workers))
+	for i, w := range workers {
+		aw, ok := w.(*internal.AggregatedWorker)
+		if !ok {
+			panic("Worker must be created with worker.New()")
+		}
+		aggregatedWorkers[i] = aw
+	}
+	return internal.NewWorkerHealthHandler(options, aggregatedWorkers...)
+}
+
+// Drain prepares the given worker, which must have been created with New, for
+// stopping. The worker stops polling for new activity and Nexus tasks and no
+// longer accepts eager activities, but keeps processing workflow tasks so cached
+// workflows do not have their sticky workflow tasks time out. Drain blocks until
+// every activity and Nexus task has completed, including the ones delivered by
+// polls that were outstanding when Drain was called, returning ctx.Err() if ctx
+// is done first. Outstanding polls may take up to the server's long poll timeout
+// to return. Progress is reported through Options.OnDrainProgress and the
+// temporal_worker_drain_in_flight_tasks metric.
+//
+// Draining cannot be undone; call Stop once Drain returns.
+//
+// NOTE: Experimental
+func Drain(ctx context.Context, w Worker) error {
+	aw, ok := w.(*internal.AggregatedWorker)
+	if !ok {
+		return errors.New("worker must be created with worker.New()")
+	}
+	return aw.Drain(ctx)
+}
+
 // NewWorkflowReplayer creates a WorkflowReplayer instance.
 func NewWorkflowReplayer() WorkflowReplayer {
 	w, err := NewWorkflowReplayerWithOptions(WorkflowReplayerOptions{})
@@ -314,6 +399,31 @@ func InterruptCh() <-chan interface{} {
 	return internal.InterruptCh()
 }
 
+// NewActivityRateLimiter returns an ActivityRateLimiter that limits each key
+// to a rate local to this process.
+//
+// NOTE: Experimental
+func NewActivityRateLimiter(options ActivityRateLimiterOptions) ActivityRateLimiter {
+	return internal.NewActivityRateLimiter(options)
+}
+
+// ActivityRateLimitKeyByType returns a key function for
+// ActivityRateLimitOptions that keys on the activity type.
+//
+// NOTE: Experimental
+func ActivityRateLimitKeyByType() func(activity.Info, workflow.HeaderReader) string {
+	return internal.ActivityRateLimitKeyByType()
+}
+
+// ActivityRateLimitKeyByHeader returns a key function for
+// ActivityRateLimitOptions that keys on the string value of the given header
+// field. Tasks without the header are not rate limited.
+//
+// NOTE: Experimental
+func ActivityRateLimitKeyByHeader(headerKey string) func(activity.Info, workflow.HeaderReader) string {
+	return internal.ActivityRateLimitKeyByHeader(headerKey)
+}
+
 // NewPollerBehaviorSimpleMaximum creates a PollerBehavior that allows the worker to start up to a maximum number of pollers.
 func NewPollerBehaviorSimpleMaximum(
 	options PollerBehaviorSimpleMaximumOptions,